@@ -0,0 +1,965 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	halPath     = "github.com/pmoule/go2hal/hal"
+	mappingPath = "github.com/pmoule/go2hal/hal/mapping"
+)
+
+// valueKind classifies a field type by its syntax. Types not known by the generator
+// are mapped by mapping.MapValue.
+type valueKind int
+
+const (
+	otherValue valueKind = iota
+	basicValue
+	floatValue
+	timeValue
+	basicPointerValue
+	basicSliceValue
+	mapValue
+	// values of generated types without marshaler methods
+	generatedValue
+	generatedPointerValue
+	generatedSliceValue
+)
+
+// structDecl is a struct type declared in the parsed package.
+type structDecl struct {
+	name    string
+	typ     *ast.StructType
+	imports map[string]string
+}
+
+// dataField is a field mapped to a property.
+type dataField struct {
+	name      string
+	goName    string
+	selector  string
+	guards    []string
+	depth     int
+	tagged    bool
+	typ       ast.Expr
+	imports   map[string]string
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+}
+
+// halField is a field tagged as link or embedded resource.
+type halField struct {
+	kind         string
+	relationName string
+	goName       string
+	selector     string
+	guards       []string
+	typ          ast.Expr
+	imports      map[string]string
+}
+
+type generator struct {
+	packageName  string
+	structs      map[string]*structDecl
+	marshalers   map[string]bool
+	generated    map[string]bool
+	withResource bool
+	imports      map[string]bool
+	buffer       bytes.Buffer
+}
+
+// generate returns the formatted source of ToPropertyMap and optionally ToResource methods
+// for the struct types of the package in dir. The output file is not parsed.
+func generate(dir string, typeNames []string, withResource bool, output string) ([]byte, error) {
+	g := &generator{
+		structs:      map[string]*structDecl{},
+		marshalers:   map[string]bool{},
+		generated:    map[string]bool{},
+		withResource: withResource,
+		imports:      map[string]bool{mappingPath: true},
+	}
+
+	if err := g.parsePackage(dir, output); err != nil {
+		return nil, err
+	}
+
+	for _, name := range typeNames {
+		if _, ok := g.structs[name]; !ok {
+			return nil, fmt.Errorf("struct type %s not found in package %s", name, g.packageName)
+		}
+
+		g.generated[name] = true
+	}
+
+	for _, name := range typeNames {
+		if err := g.generatePropertyMapper(g.structs[name]); err != nil {
+			return nil, err
+		}
+
+		if withResource {
+			if err := g.generateResource(g.structs[name]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	source := g.header()
+	source = append(source, g.buffer.Bytes()...)
+	formatted, err := format.Source(source)
+
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// parsePackage reads all struct types of the non-test files in dir.
+func (g *generator) parsePackage(dir string, output string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+
+	if err != nil {
+		return err
+	}
+
+	fileSet := token.NewFileSet()
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == filepath.Base(output) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, path, nil, parser.SkipObjectResolution)
+
+		if err != nil {
+			return err
+		}
+
+		if g.packageName == "" {
+			g.packageName = file.Name.Name
+		}
+
+		if file.Name.Name != g.packageName {
+			continue
+		}
+
+		imports := readImports(file)
+
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				g.readMarshaler(funcDecl)
+
+				continue
+			}
+
+			genDecl, ok := decl.(*ast.GenDecl)
+
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok && typeSpec.TypeParams == nil {
+					g.structs[typeSpec.Name.Name] = &structDecl{name: typeSpec.Name.Name, typ: structType, imports: imports}
+				}
+			}
+		}
+	}
+
+	if g.packageName == "" {
+		return fmt.Errorf("no Go files in %s", dir)
+	}
+
+	return nil
+}
+
+// readMarshaler records the receiver type of MarshalJSON and MarshalText methods.
+func (g *generator) readMarshaler(decl *ast.FuncDecl) {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return
+	}
+
+	if name := decl.Name.Name; name == "MarshalJSON" || name == "MarshalText" {
+		g.marshalers[embeddedName(decl.Recv.List[0].Type)] = true
+	}
+}
+
+// readImports returns the import paths of a file by their local name.
+func readImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = path
+	}
+
+	return imports
+}
+
+func (g *generator) header() []byte {
+	paths := []string{}
+
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		if isHAL := strings.HasPrefix(paths[i], halPath); isHAL != strings.HasPrefix(paths[j], halPath) {
+			return !isHAL
+		}
+
+		return paths[i] < paths[j]
+	})
+	header := &bytes.Buffer{}
+	fmt.Fprintf(header, "// Code generated by go2hal-gen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.packageName)
+	standard := true
+
+	for _, path := range paths {
+		// standard library imports are grouped before go2hal imports
+		if standard && strings.HasPrefix(path, halPath) {
+			standard = false
+
+			if path != paths[0] {
+				fmt.Fprintf(header, "\n")
+			}
+		}
+
+		fmt.Fprintf(header, "\t%q\n", path)
+	}
+
+	fmt.Fprintf(header, ")\n")
+
+	return header.Bytes()
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buffer, format, args...)
+}
+
+// collectFields selects the data and hal fields of a struct type the same way
+// mapping.MapData and hal.FromStruct do.
+func (g *generator) collectFields(decl *structDecl) ([]dataField, []halField, error) {
+	fields := []dataField{}
+	halFields := []halField{}
+	visited := map[string]bool{}
+
+	var collect func(decl *structDecl, selector string, guards []string, depth int, withData bool) error
+
+	// hal tagged fields of embedded structs are promoted, even if the struct is no data
+	collect = func(decl *structDecl, selector string, guards []string, depth int, withData bool) error {
+		if visited[decl.name] {
+			return nil
+		}
+
+		visited[decl.name] = true
+		defer delete(visited, decl.name)
+
+		for _, field := range decl.typ.Fields.List {
+			tag := reflect.StructTag("")
+
+			if field.Tag != nil {
+				value, _ := strconv.Unquote(field.Tag.Value)
+				tag = reflect.StructTag(value)
+			}
+
+			names := []string{}
+
+			for _, name := range field.Names {
+				names = append(names, name.Name)
+			}
+
+			embedded := ""
+
+			if len(names) == 0 {
+				embedded = embeddedName(field.Type)
+
+				if embedded == "" {
+					return fmt.Errorf("%s: unsupported embedded field type", decl.name)
+				}
+
+				names = append(names, embedded)
+			}
+
+			for _, goName := range names {
+				fieldSelector := selector + "." + goName
+
+				if halTag, ok := tag.Lookup("hal"); ok {
+					if !ast.IsExported(goName) {
+						return fmt.Errorf("%s.%s: tagged field must be exported", decl.name, goName)
+					}
+
+					kind, relationName, err := readHALTag(halTag, goName)
+
+					if err != nil {
+						return fmt.Errorf("%s.%s: %w", decl.name, goName, err)
+					}
+
+					halFields = append(halFields, halField{kind, relationName, goName, fieldSelector, guards, field.Type, decl.imports})
+
+					continue
+				}
+
+				jsonTag := tag.Get("json")
+				name, options := parseTag(jsonTag)
+
+				if !isValidTag(name) {
+					name = ""
+				}
+
+				if embedded != "" {
+					target, isPointer := g.embeddedStruct(decl, field.Type)
+
+					if target == nil && strings.Contains(embedded, ".") {
+						return fmt.Errorf("%s: embedded field %s of another package is not supported", decl.name, embedded)
+					}
+
+					if target != nil {
+						targetGuards := guards
+
+						if isPointer {
+							targetGuards = append(append([]string{}, guards...), fieldSelector)
+						}
+
+						promoted := withData && jsonTag != "-" && name == ""
+
+						if err := collect(target, fieldSelector, targetGuards, depth+1, promoted); err != nil {
+							return err
+						}
+
+						if promoted {
+							continue
+						}
+					}
+				}
+
+				if !withData || !ast.IsExported(goName) || jsonTag == "-" {
+					continue
+				}
+
+				tagged := name != ""
+
+				if !tagged {
+					name = goName
+				}
+
+				fields = append(fields, dataField{
+					name:      name,
+					goName:    goName,
+					selector:  fieldSelector,
+					guards:    guards,
+					depth:     depth,
+					tagged:    tagged,
+					typ:       field.Type,
+					imports:   decl.imports,
+					omitEmpty: hasOption(options, "omitempty"),
+					omitZero:  hasOption(options, "omitzero"),
+					quoted:    hasOption(options, "string"),
+				})
+			}
+		}
+
+		return nil
+	}
+
+	if err := collect(decl, "v", nil, 0, true); err != nil {
+		return nil, nil, err
+	}
+
+	return dominantFields(fields), halFields, nil
+}
+
+// dominantFields removes fields hidden by other fields with the same name. The least nested
+// field is chosen, a tagged one is preferred over an untagged one. Otherwise all of them are ignored.
+func dominantFields(fields []dataField) []dataField {
+	byName := map[string][]dataField{}
+
+	for _, field := range fields {
+		byName[field.name] = append(byName[field.name], field)
+	}
+
+	result := []dataField{}
+
+	for _, field := range fields {
+		candidates := byName[field.name]
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].depth != candidates[j].depth {
+				return candidates[i].depth < candidates[j].depth
+			}
+
+			return candidates[i].tagged && !candidates[j].tagged
+		})
+
+		if len(candidates) > 1 && candidates[0].depth == candidates[1].depth && candidates[0].tagged == candidates[1].tagged {
+			continue
+		}
+
+		if candidates[0].selector == field.selector {
+			result = append(result, field)
+		}
+	}
+
+	return result
+}
+
+// embeddedName returns the field name of an embedded field type.
+func embeddedName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			return x.Name + "." + t.Sel.Name
+		}
+	}
+
+	return ""
+}
+
+// embeddedStruct returns the struct type of an embedded field declared in the parsed package.
+func (g *generator) embeddedStruct(decl *structDecl, expr ast.Expr) (*structDecl, bool) {
+	star, isPointer := expr.(*ast.StarExpr)
+
+	if isPointer {
+		expr = star.X
+	}
+
+	ident, ok := expr.(*ast.Ident)
+
+	if !ok {
+		return nil, false
+	}
+
+	return g.structs[ident.Name], isPointer
+}
+
+func (g *generator) generatePropertyMapper(decl *structDecl) error {
+	fields, _, err := g.collectFields(decl)
+
+	if err != nil {
+		return err
+	}
+
+	g.printf("\n// ToPropertyMap maps %s to a mapping.PropertyMap without reflection.\n", decl.name)
+	g.printf("func (v %s) ToPropertyMap() (mapping.PropertyMap, error) {\n", decl.name)
+	g.printf("propertyMap := make(mapping.PropertyMap, %d)\n", len(fields))
+	g.printf("var firstErr error\n")
+
+	for _, field := range fields {
+		if err := g.generateField(decl, field); err != nil {
+			return err
+		}
+	}
+
+	g.printf("\nreturn propertyMap, firstErr\n}\n")
+
+	return nil
+}
+
+func (g *generator) generateField(decl *structDecl, field dataField) error {
+	kind := g.classify(field.typ, field.imports)
+	x := field.selector
+	conditions := guardConditions(field.guards)
+
+	if field.omitEmpty {
+		if condition := nonEmptyCondition(kind, field.typ, x); condition != "" {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if field.omitZero {
+		conditions = append(conditions, nonZeroCondition(kind, field.typ, x))
+	}
+
+	g.printf("\n")
+
+	if len(conditions) > 0 {
+		g.printf("if %s {\n", strings.Join(conditions, " && "))
+	}
+
+	setError := func(err string) {
+		g.printf("if firstErr == nil {\nfirstErr = mapping.WithFieldPath(%s, %q)\n}\n", err, "."+field.goName)
+	}
+
+	quoting := noQuoting
+
+	if field.quoted {
+		quoting = g.readQuoting(field.typ)
+	}
+
+	switch {
+	case quoting == unknownQuoting:
+		return fmt.Errorf("%s.%s: string option requires a predeclared type", decl.name, field.goName)
+	case quoting == valueQuoting:
+		g.imports["encoding/json"] = true
+		g.printf("if b, err := json.Marshal(%s); err != nil {\n", x)
+		setError("err")
+		g.printf("} else {\npropertyMap[%q] = string(b)\n}\n", field.name)
+	case quoting == pointerQuoting:
+		g.imports["encoding/json"] = true
+		g.printf("if %s == nil {\npropertyMap[%q] = nil\n} else if b, err := json.Marshal(*%s); err != nil {\n", x, field.name, x)
+		setError("err")
+		g.printf("} else {\npropertyMap[%q] = string(b)\n}\n", field.name)
+	case kind == basicValue || kind == timeValue || kind == mapValue:
+		g.printf("propertyMap[%q] = %s\n", field.name, x)
+	case kind == floatValue:
+		g.imports["fmt"] = true
+		g.imports["math"] = true
+		g.imports["strconv"] = true
+		g.printf("if math.IsNaN(float64(%s)) || math.IsInf(float64(%s), 0) {\n", x, x)
+		setError(fmt.Sprintf("fmt.Errorf(\"unsupported value: %%s\", strconv.FormatFloat(float64(%s), 'g', -1, 64))", x))
+		g.printf("} else {\npropertyMap[%q] = %s\n}\n", field.name, x)
+	case kind == basicPointerValue && (field.omitEmpty || field.omitZero):
+		// nil is omitted
+		g.printf("propertyMap[%q] = *%s\n", field.name, x)
+	case kind == basicSliceValue && (field.omitEmpty || field.omitZero):
+		g.printf("propertyMap[%q] = %s\n", field.name, x)
+	case kind == generatedValue || kind == generatedPointerValue:
+		if kind == generatedPointerValue && !field.omitEmpty && !field.omitZero {
+			g.printf("if %s == nil {\npropertyMap[%q] = nil\n} else ", x, field.name)
+		}
+
+		g.printf("if value, err := %s.ToPropertyMap(); err != nil {\n", x)
+		setError("err")
+		g.printf("\npropertyMap[%q] = value\n} else {\npropertyMap[%q] = value\n}\n", field.name, field.name)
+	case kind == generatedSliceValue:
+		g.imports["strconv"] = true
+
+		if !field.omitEmpty && !field.omitZero {
+			g.printf("if %s == nil {\npropertyMap[%q] = nil\n} else {\n", x, field.name)
+		}
+
+		g.printf("values := make([]mapping.PropertyMap, 0, len(%s))\n\nfor i, item := range %s {\n", x, x)
+		g.printf("value, err := item.ToPropertyMap()\n\nif err != nil && firstErr == nil {\n")
+		g.printf("firstErr = mapping.WithFieldPath(err, %q+strconv.Itoa(i)+\"]\")\n}\n\n", "."+field.goName+"[")
+		g.printf("values = append(values, value)\n}\n\npropertyMap[%q] = values\n", field.name)
+
+		if !field.omitEmpty && !field.omitZero {
+			g.printf("}\n")
+		}
+	case kind == basicPointerValue:
+		g.printf("if %s != nil {\npropertyMap[%q] = *%s\n} else {\npropertyMap[%q] = nil\n}\n", x, field.name, x, field.name)
+	case kind == basicSliceValue:
+		g.printf("if %s != nil {\npropertyMap[%q] = %s\n} else {\npropertyMap[%q] = nil\n}\n", x, field.name, x, field.name)
+	default:
+		g.printf("if value, err := mapping.MapValue(&%s); err != nil {\n", x)
+		setError("err")
+		g.printf("\nif value != nil {\npropertyMap[%q] = value\n}\n", field.name)
+		g.printf("} else {\npropertyMap[%q] = value\n}\n", field.name)
+	}
+
+	if len(conditions) > 0 {
+		g.printf("}\n")
+	}
+
+	return nil
+}
+
+// nonEmptyCondition returns the condition of a value not empty as defined by the omitempty option.
+// Values never being empty have no condition.
+func nonEmptyCondition(kind valueKind, typ ast.Expr, x string) string {
+	switch kind {
+	case basicValue, floatValue:
+		return nonZeroBasic(typ.(*ast.Ident).Name, x)
+	case timeValue, generatedValue:
+		return ""
+	case basicPointerValue, generatedPointerValue:
+		return x + " != nil"
+	case basicSliceValue, mapValue, generatedSliceValue:
+		return "len(" + x + ") != 0"
+	}
+
+	return "!mapping.IsEmpty(" + x + ")"
+}
+
+// nonZeroCondition returns the condition of a value not zero as defined by the omitzero option.
+func nonZeroCondition(kind valueKind, typ ast.Expr, x string) string {
+	switch kind {
+	case basicValue, floatValue:
+		return nonZeroBasic(typ.(*ast.Ident).Name, x)
+	case timeValue:
+		return "!" + x + ".IsZero()"
+	case basicPointerValue, basicSliceValue, mapValue, generatedPointerValue, generatedSliceValue:
+		return x + " != nil"
+	}
+
+	return "!mapping.IsZero(" + x + ")"
+}
+
+func nonZeroBasic(typeName string, x string) string {
+	switch typeName {
+	case "string":
+		return x + ` != ""`
+	case "bool":
+		return x
+	}
+
+	return x + " != 0"
+}
+
+// classify returns the valueKind of a field type.
+func (g *generator) classify(typ ast.Expr, imports map[string]string) valueKind {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if t.Name == "float32" || t.Name == "float64" {
+			return floatValue
+		}
+
+		if isBasic(t.Name) {
+			return basicValue
+		}
+
+		if g.isGenerated(t) {
+			return generatedValue
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && imports[x.Name] == "time" && t.Sel.Name == "Time" {
+			return timeValue
+		}
+	case *ast.StarExpr:
+		if ident, ok := t.X.(*ast.Ident); ok && isBasic(ident.Name) {
+			return basicPointerValue
+		}
+
+		if g.isGenerated(t.X) {
+			return generatedPointerValue
+		}
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && (isBasic(ident.Name) || ident.Name == "float32" || ident.Name == "float64") {
+			return basicSliceValue
+		}
+
+		if t.Len == nil && g.isGenerated(t.Elt) {
+			return generatedSliceValue
+		}
+	case *ast.MapType:
+		// maps are kept as they are
+		return mapValue
+	}
+
+	return otherValue
+}
+
+// isGenerated checks whether typ is a type with a generated ToPropertyMap method used by reflection as well.
+func (g *generator) isGenerated(typ ast.Expr) bool {
+	ident, ok := typ.(*ast.Ident)
+
+	return ok && g.generated[ident.Name] && !g.marshalers[ident.Name]
+}
+
+// isBasic checks whether a type name is a predeclared boolean, string or integer type.
+func isBasic(name string) bool {
+	switch name {
+	case "bool", "string", "byte", "rune",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		return true
+	}
+
+	return false
+}
+
+// quoting defines how the string option of a field is applied.
+type quoting int
+
+const (
+	noQuoting quoting = iota
+	valueQuoting
+	pointerQuoting
+	// the underlying type is declared elsewhere and may be a basic type
+	unknownQuoting
+)
+
+// readQuoting returns how the string option applies to a field type. Like encoding/json, the option
+// is ignored for types other than strings, booleans and numbers or pointers to them.
+func (g *generator) readQuoting(typ ast.Expr) quoting {
+	result := valueQuoting
+
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+		result = pointerQuoting
+	}
+
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if isBasic(t.Name) || t.Name == "float32" || t.Name == "float64" {
+			return result
+		}
+
+		if t.Name == "any" || g.structs[t.Name] != nil {
+			return noQuoting
+		}
+
+		return unknownQuoting
+	case *ast.SelectorExpr:
+		return unknownQuoting
+	}
+
+	return noQuoting
+}
+
+func (g *generator) generateResource(decl *structDecl) error {
+	_, halFields, err := g.collectFields(decl)
+
+	if err != nil {
+		return err
+	}
+
+	g.imports[halPath] = true
+	g.printf("\n// ToResource creates a hal.Resource of %s. Data is assigned by ToPropertyMap,\n", decl.name)
+	g.printf("// tagged fields are added as link relations and embedded resources the same way hal.FromStruct does.\n")
+	g.printf("func (v %s) ToResource() (hal.Resource, error) {\n", decl.name)
+	g.printf("resource := hal.NewResourceObject()\n\nif err := resource.(hal.DataAdderE).AddDataE(v); err != nil {\nreturn nil, err\n}\n")
+
+	for _, field := range halFields {
+		var err error
+
+		switch field.kind {
+		case linkTagKind:
+			err = g.generateLinkField(decl, field)
+		case embeddedTagKind:
+			err = g.generateEmbeddedField(decl, field)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	g.printf("\nreturn resource, nil\n}\n")
+
+	return nil
+}
+
+func (g *generator) generateLinkField(decl *structDecl, field halField) error {
+	x := field.selector
+	typ := field.typ
+	isSlice := false
+
+	if array, ok := typ.(*ast.ArrayType); ok && array.Len == nil {
+		typ = array.Elt
+		isSlice = true
+	}
+
+	star, ok := typ.(*ast.StarExpr)
+
+	if !ok || !g.isHALType(star.X, field.imports, "LinkObject") {
+		return fmt.Errorf("%s.%s: link requires type *hal.LinkObject or []*hal.LinkObject", decl.name, field.goName)
+	}
+
+	g.imports["fmt"] = true
+	g.printf("\nif %s {\n", strings.Join(append(guardConditions(field.guards), x+" != nil"), " && "))
+
+	if isSlice {
+		g.imports["errors"] = true
+		g.printf("for _, link := range %s {\nif link == nil {\nreturn nil, errors.New(%q)\n}\n}\n\n", x, "field "+field.goName+": link must not be nil")
+	}
+
+	g.printf("relation, err := hal.NewLinkRelation(%q)\n\n", field.relationName)
+	g.printf("if err != nil {\nreturn nil, fmt.Errorf(%q, err)\n}\n\n", "field "+field.goName+": %w")
+
+	if isSlice {
+		g.printf("relation.SetLinks(%s)\n", x)
+	} else {
+		g.printf("relation.SetLink(%s)\n", x)
+	}
+
+	g.printf("resource.AddLink(relation)\n}\n")
+
+	return nil
+}
+
+func (g *generator) generateEmbeddedField(decl *structDecl, field halField) error {
+	x := field.selector
+	typ := field.typ
+	array, isSlice := typ.(*ast.ArrayType)
+
+	if isSlice && array.Len == nil {
+		typ = array.Elt
+	} else {
+		isSlice = false
+	}
+
+	conditions := guardConditions(field.guards)
+	_, isPointer := typ.(*ast.StarExpr)
+	isResource := g.isHALType(typ, field.imports, "Resource")
+
+	if isSlice || isPointer || isResource {
+		conditions = append(conditions, x+" != nil")
+	}
+
+	g.imports["fmt"] = true
+	g.printf("\n")
+
+	// each relation is declared in its own block
+	if len(conditions) > 0 {
+		g.printf("if %s {\n", strings.Join(conditions, " && "))
+	} else {
+		g.printf("{\n")
+	}
+
+	g.printf("relation, err := hal.NewResourceRelation(%q)\n\n", field.relationName)
+	g.printf("if err != nil {\nreturn nil, fmt.Errorf(%q, err)\n}\n\n", "field "+field.goName+": %w")
+
+	if isSlice {
+		g.printf("resources := make([]hal.Resource, 0, len(%s))\n\n", x)
+		g.printf("for i, item := range %s {\n", x)
+		g.generateEmbeddedResource(typ, field.imports, "item", true, fmt.Sprintf("fmt.Errorf(%q, i, err)", "field "+field.goName+": index %d: %w"))
+		g.printf("resources = append(resources, embedded)\n}\n\nrelation.SetResources(resources)\n")
+	} else {
+		g.generateEmbeddedResource(typ, field.imports, x, false, fmt.Sprintf("fmt.Errorf(%q, err)", "field "+field.goName+": %w"))
+		g.printf("relation.SetResource(embedded)\n")
+	}
+
+	g.printf("resource.AddResource(relation)\n}\n")
+
+	return nil
+}
+
+// generateEmbeddedResource assigns the resource of value x to a variable named embedded.
+// Values of slice elements may be nil, other values are checked before.
+func (g *generator) generateEmbeddedResource(typ ast.Expr, imports map[string]string, x string, mayBeNil bool, wrappedErr string) {
+	if g.isHALType(typ, imports, "Resource") {
+		if mayBeNil {
+			g.imports["errors"] = true
+			g.printf("if %s == nil {\nerr := errors.New(\"embedded resource must not be nil\")\nreturn nil, %s\n}\n\n", x, wrappedErr)
+		}
+
+		g.printf("embedded := %s\n", x)
+
+		return
+	}
+
+	name := embeddedName(typ)
+	_, isPointer := typ.(*ast.StarExpr)
+
+	if !g.withResource || !g.generated[name] {
+		g.printf("embedded, err := hal.FromStruct(%s)\n\nif err != nil {\nreturn nil, %s\n}\n\n", x, wrappedErr)
+
+		return
+	}
+
+	if isPointer && mayBeNil {
+		g.imports["errors"] = true
+		g.printf("if %s == nil {\nerr := errors.New(\"FromStruct requires a non-nil value\")\nreturn nil, %s\n}\n\n", x, wrappedErr)
+	}
+
+	g.printf("embedded, err := %s.ToResource()\n\nif err != nil {\nreturn nil, %s\n}\n\n", x, wrappedErr)
+}
+
+// isHALType checks whether typ is a type of the hal package with provided name.
+func (g *generator) isHALType(typ ast.Expr, imports map[string]string, name string) bool {
+	selector, ok := typ.(*ast.SelectorExpr)
+
+	if !ok {
+		return false
+	}
+
+	x, ok := selector.X.(*ast.Ident)
+
+	return ok && imports[x.Name] == halPath && selector.Sel.Name == name
+}
+
+func guardConditions(guards []string) []string {
+	conditions := []string{}
+
+	for _, guard := range guards {
+		conditions = append(conditions, guard+" != nil")
+	}
+
+	return conditions
+}
+
+const (
+	linkTagKind     = "link"
+	embeddedTagKind = "embedded"
+)
+
+// readHALTag returns kind and relation name of a hal tag value the same way hal.FromStruct does.
+func readHALTag(tag string, fieldName string) (string, string, error) {
+	tokens := strings.Split(tag, ",")
+	kind := strings.TrimSpace(tokens[0])
+	relationName := fieldName
+
+	if kind != linkTagKind && kind != embeddedTagKind {
+		return "", "", fmt.Errorf("unknown hal tag kind %q", kind)
+	}
+
+	for _, token := range tokens[1:] {
+		option := strings.TrimSpace(token)
+
+		if !strings.HasPrefix(option, "rel=") {
+			return "", "", fmt.Errorf("unknown hal tag option %q", option)
+		}
+
+		relationName = strings.TrimPrefix(option, "rel=")
+	}
+
+	return kind, relationName, nil
+}
+
+// parseTag splits a json struct tag into name and options.
+func parseTag(tag string) (string, string) {
+	if index := strings.Index(tag, ","); index >= 0 {
+		return tag[:index], tag[index+1:]
+	}
+
+	return tag, ""
+}
+
+// hasOption checks whether comma separated options contain option.
+func hasOption(options string, option string) bool {
+	for _, value := range strings.Split(options, ",") {
+		if value == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValidTag checks whether a json struct tag name is usable as property name.
+func isValidTag(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// backslash and quote chars are reserved
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,117 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSource(t *testing.T, source string) string {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestGenerateExample(t *testing.T) {
+	output := filepath.Join("internal", "example", "order_hal.go")
+	result, err := generate(filepath.Join("internal", "example"), []string{"Order", "Line"}, true, output)
+
+	if err != nil {
+		t.Fatalf("generate returns error: %s", err)
+	}
+
+	wanted, _ := os.ReadFile(output)
+
+	if string(result) != string(wanted) {
+		t.Errorf("%s is outdated, run go generate", output)
+	}
+}
+
+func TestGenerateFields(t *testing.T) {
+	dir := writeSource(t, `package shop
+
+type Base struct {
+	ID    int    `+"`json:\"id\"`"+`
+	Name  string
+	Title string `+"`json:\"title\"`"+`
+}
+
+type Other struct {
+	Title string `+"`json:\"title\"`"+`
+	Code  string
+}
+
+type Product struct {
+	Base
+	*Other
+	Name     string `+"`json:\"name\"`"+`
+	Hidden   string `+"`json:\"-\"`"+`
+	Dash     string `+"`json:\"-,\"`"+`
+	Count    int    `+"`json:\",string\"`"+`
+	Self     string `+"`hal:\"link\"`"+`
+	internal string
+}
+`)
+	result, err := generate(dir, []string{"Product"}, false, "product_hal.go")
+
+	if err != nil {
+		t.Fatalf("generate returns error: %s", err)
+	}
+
+	source := string(result)
+	wanted := []string{
+		`propertyMap["id"] = v.Base.ID`,
+		`propertyMap["name"] = v.Name`,
+		`propertyMap["Name"] = v.Base.Name`,
+		`propertyMap["-"] = v.Dash`,
+		`if v.Other != nil {`,
+		`propertyMap["Code"] = v.Other.Code`,
+		`json.Marshal(v.Count)`,
+	}
+
+	for _, value := range wanted {
+		if !strings.Contains(source, value) {
+			t.Errorf("Generated code does not contain %s", value)
+		}
+	}
+
+	unwanted := []string{`"title"`, `"Hidden"`, `"Self"`, `"internal"`}
+
+	for _, value := range unwanted {
+		if strings.Contains(source, value) {
+			t.Errorf("Generated code contains %s", value)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		source   string
+		typeName string
+		message  string
+	}{
+		{"package shop\n\ntype Order struct{}\n", "Missing", "not found"},
+		{"package shop\n\ntype Status string\n\ntype Order struct {\n\tStatus Status `json:\",string\"`\n}\n", "Order", "string option"},
+		{"package shop\n\nimport \"time\"\n\ntype Order struct {\n\ttime.Time\n}\n", "Order", "another package"},
+		{"package shop\n\ntype Order struct {\n\tSelf string `hal:\"reference\"`\n}\n", "Order", "unknown hal tag kind"},
+		{"package shop\n\ntype Order struct {\n\tSelf string `hal:\"link\"`\n}\n", "Order", "link requires type"},
+	}
+
+	for _, test := range tests {
+		dir := writeSource(t, test.source)
+		_, err := generate(dir, []string{test.typeName}, true, "order_hal.go")
+
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("Error is %v, want error containing %q", err, test.message)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+// Package example contains types with mappers generated by go2hal-gen.
+// Its tests compare generated mappers with mapping by reflection.
+package example
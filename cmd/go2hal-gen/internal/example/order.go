@@ -0,0 +1,69 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package example
+
+import (
+	"time"
+
+	"github.com/pmoule/go2hal/hal"
+)
+
+//go:generate go run github.com/pmoule/go2hal/cmd/go2hal-gen -type Order,Line -resource
+
+// Status is the processing state of an Order.
+type Status int
+
+const (
+	Open Status = iota
+	Shipped
+)
+
+// MarshalText returns the name of a Status.
+func (s Status) MarshalText() ([]byte, error) {
+	if s == Shipped {
+		return []byte("shipped"), nil
+	}
+
+	return []byte("open"), nil
+}
+
+// Audit holds the revision of a stored Order.
+type Audit struct {
+	Version int       `json:"version"`
+	Updated time.Time `json:"updated,omitzero"`
+}
+
+// Line is an ordered product.
+type Line struct {
+	Product  string  `json:"product"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price,string"`
+}
+
+// Address is a shipping address.
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city,omitempty"`
+}
+
+// Order is an order of some products.
+type Order struct {
+	Audit
+	ID         int               `json:"id"`
+	Customer   string            `json:"customer"`
+	Total      float64           `json:"total"`
+	Paid       bool              `json:"paid"`
+	Created    time.Time         `json:"created"`
+	Note       string            `json:"note,omitempty"`
+	Discount   *int              `json:"discount,omitempty"`
+	Tags       []string          `json:"tags"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Status     Status            `json:"status"`
+	Address    *Address          `json:"address,omitempty"`
+	Lines      []Line            `json:"lines"`
+	Self       *hal.LinkObject   `hal:"link,rel=self"`
+	Items      []*Line           `hal:"embedded,rel=items"`
+	internal   string
+}
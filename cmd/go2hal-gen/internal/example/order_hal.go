@@ -0,0 +1,189 @@
+// Code generated by go2hal-gen; DO NOT EDIT.
+
+package example
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/pmoule/go2hal/hal"
+	"github.com/pmoule/go2hal/hal/mapping"
+)
+
+// ToPropertyMap maps Order to a mapping.PropertyMap without reflection.
+func (v Order) ToPropertyMap() (mapping.PropertyMap, error) {
+	propertyMap := make(mapping.PropertyMap, 14)
+	var firstErr error
+
+	propertyMap["version"] = v.Audit.Version
+
+	if !v.Audit.Updated.IsZero() {
+		propertyMap["updated"] = v.Audit.Updated
+	}
+
+	propertyMap["id"] = v.ID
+
+	propertyMap["customer"] = v.Customer
+
+	if math.IsNaN(float64(v.Total)) || math.IsInf(float64(v.Total), 0) {
+		if firstErr == nil {
+			firstErr = mapping.WithFieldPath(fmt.Errorf("unsupported value: %s", strconv.FormatFloat(float64(v.Total), 'g', -1, 64)), ".Total")
+		}
+	} else {
+		propertyMap["total"] = v.Total
+	}
+
+	propertyMap["paid"] = v.Paid
+
+	propertyMap["created"] = v.Created
+
+	if v.Note != "" {
+		propertyMap["note"] = v.Note
+	}
+
+	if v.Discount != nil {
+		propertyMap["discount"] = *v.Discount
+	}
+
+	if v.Tags != nil {
+		propertyMap["tags"] = v.Tags
+	} else {
+		propertyMap["tags"] = nil
+	}
+
+	if len(v.Attributes) != 0 {
+		propertyMap["attributes"] = v.Attributes
+	}
+
+	if value, err := mapping.MapValue(&v.Status); err != nil {
+		if firstErr == nil {
+			firstErr = mapping.WithFieldPath(err, ".Status")
+		}
+
+		if value != nil {
+			propertyMap["status"] = value
+		}
+	} else {
+		propertyMap["status"] = value
+	}
+
+	if !mapping.IsEmpty(v.Address) {
+		if value, err := mapping.MapValue(&v.Address); err != nil {
+			if firstErr == nil {
+				firstErr = mapping.WithFieldPath(err, ".Address")
+			}
+
+			if value != nil {
+				propertyMap["address"] = value
+			}
+		} else {
+			propertyMap["address"] = value
+		}
+	}
+
+	if v.Lines == nil {
+		propertyMap["lines"] = nil
+	} else {
+		values := make([]mapping.PropertyMap, 0, len(v.Lines))
+
+		for i, item := range v.Lines {
+			value, err := item.ToPropertyMap()
+
+			if err != nil && firstErr == nil {
+				firstErr = mapping.WithFieldPath(err, ".Lines["+strconv.Itoa(i)+"]")
+			}
+
+			values = append(values, value)
+		}
+
+		propertyMap["lines"] = values
+	}
+
+	return propertyMap, firstErr
+}
+
+// ToResource creates a hal.Resource of Order. Data is assigned by ToPropertyMap,
+// tagged fields are added as link relations and embedded resources the same way hal.FromStruct does.
+func (v Order) ToResource() (hal.Resource, error) {
+	resource := hal.NewResourceObject()
+
+	if err := resource.(hal.DataAdderE).AddDataE(v); err != nil {
+		return nil, err
+	}
+
+	if v.Self != nil {
+		relation, err := hal.NewLinkRelation("self")
+
+		if err != nil {
+			return nil, fmt.Errorf("field Self: %w", err)
+		}
+
+		relation.SetLink(v.Self)
+		resource.AddLink(relation)
+	}
+
+	if v.Items != nil {
+		relation, err := hal.NewResourceRelation("items")
+
+		if err != nil {
+			return nil, fmt.Errorf("field Items: %w", err)
+		}
+
+		resources := make([]hal.Resource, 0, len(v.Items))
+
+		for i, item := range v.Items {
+			if item == nil {
+				err := errors.New("FromStruct requires a non-nil value")
+				return nil, fmt.Errorf("field Items: index %d: %w", i, err)
+			}
+
+			embedded, err := item.ToResource()
+
+			if err != nil {
+				return nil, fmt.Errorf("field Items: index %d: %w", i, err)
+			}
+
+			resources = append(resources, embedded)
+		}
+
+		relation.SetResources(resources)
+		resource.AddResource(relation)
+	}
+
+	return resource, nil
+}
+
+// ToPropertyMap maps Line to a mapping.PropertyMap without reflection.
+func (v Line) ToPropertyMap() (mapping.PropertyMap, error) {
+	propertyMap := make(mapping.PropertyMap, 3)
+	var firstErr error
+
+	propertyMap["product"] = v.Product
+
+	propertyMap["quantity"] = v.Quantity
+
+	if b, err := json.Marshal(v.Price); err != nil {
+		if firstErr == nil {
+			firstErr = mapping.WithFieldPath(err, ".Price")
+		}
+	} else {
+		propertyMap["price"] = string(b)
+	}
+
+	return propertyMap, firstErr
+}
+
+// ToResource creates a hal.Resource of Line. Data is assigned by ToPropertyMap,
+// tagged fields are added as link relations and embedded resources the same way hal.FromStruct does.
+func (v Line) ToResource() (hal.Resource, error) {
+	resource := hal.NewResourceObject()
+
+	if err := resource.(hal.DataAdderE).AddDataE(v); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
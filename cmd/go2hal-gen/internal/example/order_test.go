@@ -0,0 +1,181 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package example
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pmoule/go2hal/hal"
+	"github.com/pmoule/go2hal/hal/mapping"
+)
+
+// plainOrder and plainLine have no generated methods, so they are mapped by reflection.
+type plainOrder Order
+
+type plainLine Line
+
+func createOrder() Order {
+	discount := 5
+	self, _ := hal.NewLinkObject("/docwhoapi/orders/1")
+
+	return Order{
+		Audit:      Audit{Version: 2, Updated: time.Date(2005, 3, 26, 19, 0, 0, 0, time.UTC)},
+		ID:         1,
+		Customer:   "Rose Tyler",
+		Total:      42.5,
+		Created:    time.Date(2005, 3, 26, 18, 0, 0, 0, time.UTC),
+		Discount:   &discount,
+		Tags:       []string{"companion"},
+		Attributes: map[string]string{"era": "Ninth Doctor"},
+		Status:     Shipped,
+		Address:    &Address{Street: "Powell Estate", City: "London"},
+		Lines:      []Line{{Product: "TARDIS key", Quantity: 1, Price: 42.5}},
+		Self:       self,
+		Items:      []*Line{{Product: "TARDIS key", Quantity: 1, Price: 42.5}},
+	}
+}
+
+func TestToPropertyMap(t *testing.T) {
+	orders := []Order{createOrder(), {}}
+
+	for _, order := range orders {
+		result, err := order.ToPropertyMap()
+
+		if err != nil {
+			t.Fatalf("ToPropertyMap returns error: %s", err)
+		}
+
+		wanted, _ := mapping.MapDataE(plainOrder(order))
+
+		if !reflect.DeepEqual(result, wanted) {
+			t.Errorf("PropertyMap is %v, want %v", result, wanted)
+		}
+	}
+
+	line := Line{Product: "TARDIS key", Quantity: 1, Price: 42.5}
+	result, _ := line.ToPropertyMap()
+	wanted := mapping.MapData(plainLine(line))
+
+	if !reflect.DeepEqual(result, wanted) {
+		t.Errorf("PropertyMap is %v, want %v", result, wanted)
+	}
+}
+
+// extendedLine embeds a type with generated methods, which are promoted to extendedLine.
+type extendedLine struct {
+	Line
+	Extra string `json:"extra"`
+}
+
+func TestMapDataWithEmbeddedGeneratedType(t *testing.T) {
+	line := extendedLine{Line{Product: "TARDIS key", Quantity: 1, Price: 42.5}, "spare"}
+	result, err := json.Marshal(mapping.MapData(line))
+
+	if err != nil {
+		t.Fatalf("Marshal returns error: %s", err)
+	}
+
+	wanted, _ := json.Marshal(line)
+	var value, wantedValue interface{}
+	_ = json.Unmarshal(result, &value)
+	_ = json.Unmarshal(wanted, &wantedValue)
+
+	if !reflect.DeepEqual(value, wantedValue) {
+		t.Errorf("JSON value is %s, want %s", result, wanted)
+	}
+}
+
+func TestToPropertyMapErrors(t *testing.T) {
+	order := createOrder()
+	order.Total = math.NaN()
+	order.Lines[0].Price = math.Inf(1)
+	_, err := mapping.MapDataE(order)
+	fieldError := &mapping.FieldError{}
+
+	if !errors.As(err, &fieldError) {
+		t.Fatalf("Error is %v, want a *mapping.FieldError", err)
+	}
+
+	if fieldError.Path != "Order.Total" {
+		t.Errorf("Path is %s, want %s", fieldError.Path, "Order.Total")
+	}
+
+	order.Total = 0
+	_, err = mapping.MapDataE(order)
+	_, wanted := mapping.MapDataE(plainOrder(order))
+
+	if err == nil || err.Error() != "Order"+wanted.Error()[len("plainOrder"):] {
+		t.Errorf("Error is %v, want same error as reflection %v", err, wanted)
+	}
+}
+
+func TestToResource(t *testing.T) {
+	order := createOrder()
+	resource, err := order.ToResource()
+
+	if err != nil {
+		t.Fatalf("ToResource returns error: %s", err)
+	}
+
+	wanted, _ := hal.FromStruct(plainOrder(order))
+	encoder := hal.NewEncoder(hal.WithOrderedProperties())
+	wantedJSON, _ := encoder.ToJSON(wanted)
+	resultJSON, err := encoder.ToJSON(resource)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	if string(resultJSON) != string(wantedJSON) {
+		t.Errorf("JSON value == %s, want %s", resultJSON, wantedJSON)
+	}
+
+	order.Items = []*Line{nil}
+
+	if _, err := order.ToResource(); err == nil {
+		t.Errorf("ToResource should return an error for nil embedded resources")
+	}
+}
+
+func BenchmarkMapDataGenerated(b *testing.B) {
+	order := createOrder()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		mapping.MapData(order)
+	}
+}
+
+func BenchmarkMapDataReflection(b *testing.B) {
+	order := plainOrder(createOrder())
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		mapping.MapData(order)
+	}
+}
+
+func BenchmarkToResource(b *testing.B) {
+	order := createOrder()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		order.ToResource()
+	}
+}
+
+func BenchmarkFromStruct(b *testing.B) {
+	order := plainOrder(createOrder())
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		hal.FromStruct(order)
+	}
+}
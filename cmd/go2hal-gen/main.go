@@ -0,0 +1,73 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+// Command go2hal-gen generates reflection-free HAL mappers for struct types.
+//
+// For each type a ToPropertyMap method implementing mapping.PropertyMapper is written.
+// Fields are selected and named by their json and hal struct tags the same way mapping.MapData
+// does, so MapData and AddData use the generated method instead of reflection.
+// With -resource, a ToResource method adding tagged links and embedded resources the same way
+// hal.FromStruct does is written as well.
+//
+// Usage:
+//
+//	//go:generate go run github.com/pmoule/go2hal/cmd/go2hal-gen -type Order,Line -resource
+//
+// Flags:
+//
+//	-type      comma separated list of struct type names, required
+//	-resource  also generate ToResource methods
+//	-output    output file name, defaults to <first type>_hal.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("go2hal-gen: ")
+
+	typeNames := flag.String("type", "", "comma separated list of struct type names")
+	withResource := flag.Bool("resource", false, "also generate ToResource methods")
+	output := flag.String("output", "", "output file name, defaults to <first type>_hal.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: go2hal-gen -type T[,T...] [-resource] [-output file] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	names := strings.Split(*typeNames, ",")
+
+	if *output == "" {
+		*output = strings.ToLower(names[0]) + "_hal.go"
+	}
+
+	path := filepath.Join(dir, *output)
+	source, err := generate(dir, names, *withResource, path)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
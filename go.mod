@@ -1,3 +1,3 @@
 module github.com/pmoule/go2hal
 
-go 1.16
+go 1.24
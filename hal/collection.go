@@ -0,0 +1,292 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+// Names of the link relations and data properties of a collection resource.
+const (
+	ItemsRelation string = "items"
+	FirstRelation string = relationtype.First
+	PrevRelation  string = relationtype.Prev
+	NextRelation  string = relationtype.Next
+	LastRelation  string = relationtype.Last
+	FindRelation  string = "find"
+	CountProperty string = "count"
+	TotalProperty string = "total"
+)
+
+// QueryStyle defines the query parameters of paging links.
+type QueryStyle int
+
+const (
+	// PageSizeStyle uses the query parameters "page", starting at 1, and "size".
+	PageSizeStyle QueryStyle = iota
+	// OffsetLimitStyle uses the query parameters "offset", starting at 0, and "limit".
+	OffsetLimitStyle
+)
+
+// sizeParameter returns the name of the query parameter limiting the amount of items.
+func (s QueryStyle) sizeParameter() string {
+	if s == OffsetLimitStyle {
+		return "limit"
+	}
+
+	return "size"
+}
+
+// positionParameter returns the name of the query parameter selecting the page.
+func (s QueryStyle) positionParameter() string {
+	if s == OffsetLimitStyle {
+		return "offset"
+	}
+
+	return "page"
+}
+
+// cursorParameter is the query parameter of cursor based paging links.
+const cursorParameter = "cursor"
+
+// Collection is a builder for collection resources. A collection resource embeds its items
+// as array and provides paging links - "self", "first", "prev", "next", "last" and a templated "find"
+// link. The data properties "count" and "total" contain the amount of items.
+//
+// Offset pagination is created by NewCollection, cursor pagination by NewCursorCollection.
+type Collection struct {
+	baseHref  string
+	page      int
+	size      int
+	total     int
+	style     QueryStyle
+	cursor    bool
+	current   string
+	prev      string
+	next      string
+	items     []Resource
+	itemsName string
+	curieLink *LinkObject
+}
+
+// NewCollection initialises a Collection for offset pagination. page starts at 1,
+// size is the maximum amount of items per page and total is the amount of all items.
+func NewCollection(baseHref string, page int, size int, total int) (*Collection, error) {
+	if page < 1 {
+		return nil, errors.New("Collection page must be greater than 0")
+	}
+
+	if size < 1 {
+		return nil, errors.New("Collection size must be greater than 0")
+	}
+
+	if total < 0 {
+		return nil, errors.New("Collection total must not be negative")
+	}
+
+	return &Collection{baseHref: baseHref, page: page, size: size, total: total, items: []Resource{}, itemsName: ItemsRelation}, nil
+}
+
+// NewCursorCollection initialises a Collection for cursor pagination. size is the maximum
+// amount of items per page. Cursors are assigned by SetCursors.
+func NewCursorCollection(baseHref string, size int) (*Collection, error) {
+	if size < 1 {
+		return nil, errors.New("Collection size must be greater than 0")
+	}
+
+	return &Collection{baseHref: baseHref, size: size, cursor: true, items: []Resource{}, itemsName: ItemsRelation}, nil
+}
+
+// SetQueryStyle defines the query parameters of paging links. Default is PageSizeStyle.
+// Cursor pagination only uses the size parameter of the style.
+func (c *Collection) SetQueryStyle(style QueryStyle) {
+	c.style = style
+}
+
+// SetItemsRelation replaces the default relation name "items" of the embedded items.
+// A CURIE link can be assigned optionally. An invalid name, e.g. an empty one, is rejected and
+// the relation is kept unchanged.
+func (c *Collection) SetItemsRelation(name string, curieLink *LinkObject) error {
+	if _, err := NewResourceRelation(name); err != nil {
+		return err
+	}
+
+	c.itemsName = name
+	c.curieLink = curieLink
+
+	return nil
+}
+
+// SetCursors assigns the cursor of the current page and the cursors of the previous and next pages
+// of a cursor pagination. Empty cursors are omitted. An empty current cursor refers to the first page.
+func (c *Collection) SetCursors(current string, prev string, next string) {
+	c.current = current
+	c.prev = prev
+	c.next = next
+}
+
+// SetItems assigns the items of the current page. slice must be a slice and itemResource
+// creates the Resource of the item at index i, the same way sort.Slice works.
+// If itemResource is nil or returns a nil Resource, an error is returned and the items are kept
+// unchanged.
+func (c *Collection) SetItems(slice interface{}, itemResource func(i int) Resource) error {
+	value := reflect.ValueOf(slice)
+
+	if value.Kind() != reflect.Slice {
+		return errors.New("Collection items must be a slice")
+	}
+
+	if itemResource == nil {
+		return errors.New("Collection item function must not be nil")
+	}
+
+	items := make([]Resource, 0, value.Len())
+
+	for i := 0; i < value.Len(); i++ {
+		item := itemResource(i)
+
+		if item == nil {
+			return fmt.Errorf("Collection item %d must not be nil", i)
+		}
+
+		items = append(items, item)
+	}
+
+	c.items = items
+
+	return nil
+}
+
+// ToResource creates the collection resource.
+func (c *Collection) ToResource() Resource {
+	resource := NewResourceObject()
+
+	if c.cursor {
+		c.addCursorLinks(resource)
+	} else {
+		c.addOffsetLinks(resource)
+	}
+
+	// the name of the items relation is validated by SetItemsRelation
+	if relation, err := NewResourceRelation(c.itemsName); err == nil {
+		if c.curieLink != nil {
+			relation.SetCurieLink(c.curieLink)
+		}
+
+		relation.SetResources(c.items)
+		resource.AddResource(relation)
+	}
+
+	resource.Data()[CountProperty] = len(c.items)
+
+	if !c.cursor {
+		resource.Data()[TotalProperty] = c.total
+	}
+
+	return resource
+}
+
+// lastPage returns the number of the last page. An empty collection has a single page.
+func (c *Collection) lastPage() int {
+	if c.total == 0 {
+		return 1
+	}
+
+	return (c.total + c.size - 1) / c.size
+}
+
+func (c *Collection) addOffsetLinks(resource Resource) {
+	last := c.lastPage()
+
+	addCollectionLink(resource, relationtype.Self, c.pageHref(c.page), false)
+	addCollectionLink(resource, FirstRelation, c.pageHref(1), false)
+
+	if c.page > 1 {
+		addCollectionLink(resource, PrevRelation, c.pageHref(min(c.page-1, last)), false)
+	}
+
+	if c.page < last {
+		addCollectionLink(resource, NextRelation, c.pageHref(c.page+1), false)
+	}
+
+	addCollectionLink(resource, LastRelation, c.pageHref(last), false)
+	addCollectionLink(resource, FindRelation, c.findHref(c.style.positionParameter()), true)
+}
+
+func (c *Collection) addCursorLinks(resource Resource) {
+	addCollectionLink(resource, relationtype.Self, c.cursorHref(c.current), false)
+	addCollectionLink(resource, FirstRelation, c.cursorHref(""), false)
+
+	if c.prev != "" {
+		addCollectionLink(resource, PrevRelation, c.cursorHref(c.prev), false)
+	}
+
+	if c.next != "" {
+		addCollectionLink(resource, NextRelation, c.cursorHref(c.next), false)
+	}
+
+	addCollectionLink(resource, FindRelation, c.findHref(cursorParameter), true)
+}
+
+// pageHref returns the href of a page using the query style.
+func (c *Collection) pageHref(page int) string {
+	position := page
+
+	if c.style == OffsetLimitStyle {
+		position = (page - 1) * c.size
+	}
+
+	return c.href(c.style.positionParameter(), strconv.Itoa(position))
+}
+
+// cursorHref returns the href of a page identified by cursor. An empty cursor refers to the first page.
+func (c *Collection) cursorHref(cursor string) string {
+	if cursor == "" {
+		return c.href("", "")
+	}
+
+	return c.href(cursorParameter, cursor)
+}
+
+// href appends the position parameter, if any, and the size parameter to the base href.
+func (c *Collection) href(parameter string, value string) string {
+	query := []string{}
+
+	if parameter != "" {
+		query = append(query, parameter+"="+url.QueryEscape(value))
+	}
+
+	query = append(query, c.style.sizeParameter()+"="+strconv.Itoa(c.size))
+
+	return c.baseHref + c.querySeparator() + strings.Join(query, "&")
+}
+
+// findHref returns a URI Template with the position and size parameters.
+func (c *Collection) findHref(parameter string) string {
+	return c.baseHref + "{" + c.querySeparator() + parameter + "," + c.style.sizeParameter() + "}"
+}
+
+// querySeparator returns "&", if the base href already has a query, otherwise "?".
+func (c *Collection) querySeparator() string {
+	if strings.Contains(c.baseHref, "?") {
+		return "&"
+	}
+
+	return "?"
+}
+
+func addCollectionLink(resource Resource, name string, href string, templated bool) {
+	link := &LinkObject{Href: href, Templated: templated}
+	relation, _ := NewLinkRelation(name)
+	relation.SetLink(link)
+	resource.AddLink(relation)
+}
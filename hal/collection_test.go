@@ -0,0 +1,190 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+func linkHrefs(resource Resource) map[string]string {
+	hrefs := map[string]string{}
+
+	for _, relation := range linkRelationsOf(resource) {
+		hrefs[relation.FullName()] = relation.Links()[0].Href
+	}
+
+	return hrefs
+}
+
+func checkLinkHrefs(t *testing.T, resource Resource, wanted map[string]string) {
+	hrefs := linkHrefs(resource)
+
+	if len(hrefs) != len(wanted) {
+		t.Errorf("Links are %v, want %v", hrefs, wanted)
+	}
+
+	for name, href := range wanted {
+		if hrefs[name] != href {
+			t.Errorf("Link %s is %q, want %q", name, hrefs[name], href)
+		}
+	}
+}
+
+func TestCollection(t *testing.T) {
+	doctors := []string{"Jodie Whittaker", "Peter Capaldi"}
+	collection, err := NewCollection("/docwhoapi/doctors", 2, 2, 13)
+
+	if err != nil {
+		t.Fatalf("NewCollection returns error: %s", err)
+	}
+
+	err = collection.SetItems(doctors, func(i int) Resource {
+		resource := NewResourceObject()
+		resource.Data()["name"] = doctors[i]
+
+		return resource
+	})
+
+	if err != nil {
+		t.Fatalf("SetItems returns error: %s", err)
+	}
+
+	resource := collection.ToResource()
+
+	checkLinkHrefs(t, resource, map[string]string{
+		relationtype.Self: "/docwhoapi/doctors?page=2&size=2",
+		FirstRelation:     "/docwhoapi/doctors?page=1&size=2",
+		PrevRelation:      "/docwhoapi/doctors?page=1&size=2",
+		NextRelation:      "/docwhoapi/doctors?page=3&size=2",
+		LastRelation:      "/docwhoapi/doctors?page=7&size=2",
+		FindRelation:      "/docwhoapi/doctors{?page,size}",
+	})
+
+	if find := resource.Links().Content[FindRelation].(*LinkObject); !find.Templated {
+		t.Errorf("Link %s should be templated", FindRelation)
+	}
+
+	if count := resource.Data()[CountProperty]; count != 2 {
+		t.Errorf("Count is %v, want %d", count, 2)
+	}
+
+	if total := resource.Data()[TotalProperty]; total != 13 {
+		t.Errorf("Total is %v, want %d", total, 13)
+	}
+
+	relations := resourceRelationsOf(resource)
+
+	if len(relations) != 1 || relations[0].FullName() != ItemsRelation || !relations[0].IsResourceSet() {
+		t.Fatalf("Resource relation %s should be a resource set", ItemsRelation)
+	}
+
+	items := relations[0].Resources()
+
+	if len(items) != len(doctors) {
+		t.Fatalf("Item count %d, want %d", len(items), len(doctors))
+	}
+
+	for i, item := range items {
+		if name := item.Data()["name"]; name != doctors[i] {
+			t.Errorf("Item %d name is %v, want %s", i, name, doctors[i])
+		}
+	}
+}
+
+func TestCollectionWithOffsetLimitStyle(t *testing.T) {
+	collection, _ := NewCollection("/docwhoapi/doctors?sort=name", 1, 5, 10)
+	collection.SetQueryStyle(OffsetLimitStyle)
+	resource := collection.ToResource()
+
+	checkLinkHrefs(t, resource, map[string]string{
+		relationtype.Self: "/docwhoapi/doctors?sort=name&offset=0&limit=5",
+		FirstRelation:     "/docwhoapi/doctors?sort=name&offset=0&limit=5",
+		NextRelation:      "/docwhoapi/doctors?sort=name&offset=5&limit=5",
+		LastRelation:      "/docwhoapi/doctors?sort=name&offset=5&limit=5",
+		FindRelation:      "/docwhoapi/doctors?sort=name{&offset,limit}",
+	})
+
+	items := resource.EmbeddedResources().Content[ItemsRelation]
+
+	if items == nil {
+		t.Errorf("Empty items should be embedded")
+	}
+}
+
+func TestCursorCollection(t *testing.T) {
+	collection, err := NewCursorCollection("/docwhoapi/doctors", 10)
+
+	if err != nil {
+		t.Fatalf("NewCursorCollection returns error: %s", err)
+	}
+
+	collection.SetCursors("b/2", "a", "c")
+	resource := collection.ToResource()
+
+	checkLinkHrefs(t, resource, map[string]string{
+		relationtype.Self: "/docwhoapi/doctors?cursor=b%2F2&size=10",
+		FirstRelation:     "/docwhoapi/doctors?size=10",
+		PrevRelation:      "/docwhoapi/doctors?cursor=a&size=10",
+		NextRelation:      "/docwhoapi/doctors?cursor=c&size=10",
+		FindRelation:      "/docwhoapi/doctors{?cursor,size}",
+	})
+
+	if _, ok := resource.Data()[TotalProperty]; ok {
+		t.Errorf("Cursor collection should not have %s", TotalProperty)
+	}
+}
+
+func TestCollectionWithInvalidValues(t *testing.T) {
+	values := [][]int{{0, 1, 0}, {1, 0, 0}, {1, 1, -1}}
+
+	for _, value := range values {
+		if _, err := NewCollection("/", value[0], value[1], value[2]); err == nil {
+			t.Errorf("NewCollection(%v) should return an error", value)
+		}
+	}
+
+	if _, err := NewCursorCollection("/", 0); err == nil {
+		t.Errorf("NewCursorCollection should return an error")
+	}
+
+	collection, _ := NewCollection("/", 1, 1, 0)
+
+	if err := collection.SetItems("test", func(i int) Resource { return NewResourceObject() }); err == nil {
+		t.Errorf("SetItems should return an error for a string")
+	}
+
+	if err := collection.SetItems([]int{1}, nil); err == nil {
+		t.Errorf("SetItems should return an error for a nil function")
+	}
+
+	if err := collection.SetItems([]int{1}, func(i int) Resource { return nil }); err == nil {
+		t.Errorf("SetItems should return an error for a nil item")
+	}
+
+	if err := collection.SetItemsRelation("", nil); err == nil {
+		t.Errorf("SetItemsRelation should return an error for an empty name")
+	}
+
+	if items := collection.ToResource().EmbeddedResources().Content[ItemsRelation]; items == nil {
+		t.Errorf("Items should be embedded as %s", ItemsRelation)
+	}
+}
+
+func TestCollectionWithItemsRelation(t *testing.T) {
+	collection, _ := NewCollection("/docwhoapi/doctors", 1, 5, 1)
+	curieLink, _ := NewCurieLink("doc", "http://example.com/docs/relations/{rel}")
+
+	if err := collection.SetItemsRelation("doctors", curieLink); err != nil {
+		t.Fatalf("SetItemsRelation returns error: %s", err)
+	}
+
+	relations := resourceRelationsOf(collection.ToResource())
+
+	if name := relations[0].FullName(); name != "doc:doctors" {
+		t.Errorf("Items relation is %s, want %s", name, "doc:doctors")
+	}
+}
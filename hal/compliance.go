@@ -0,0 +1,155 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+	"github.com/pmoule/go2hal/hal/uritemplate"
+)
+
+// Violations of the HAL draft reported by CheckCompliance. Use errors.Is to check for them.
+var (
+	ErrReservedProperty  = errors.New("data property clashes with a reserved property")
+	ErrCuriesNotArray    = errors.New("curies must be an array")
+	ErrUndefinedCurie    = errors.New("CURIE prefix has no curies entry")
+	ErrDuplicateRelation = errors.New("relation appears in compact and expanded form")
+)
+
+// CheckCompliance strictly checks a Resource against the HAL draft. Besides all problems reported
+// by Validate, these violations are reported:
+//
+// - data properties named "_links" or "_embedded", as they replace links or embedded resources
+//
+// - a "curies" relation not being an array
+//
+// - CURIE prefixes without a "curies" entry in the resource or an enclosing resource
+//
+// - a relation appearing both in compact CURIE form and in expanded URI form
+//
+// A relation name containing a colon is a compact CURIE name, unless it is a URI with "://"
+// or a URN.
+// The Resource can be created by hand or decoded. All problems are returned as ValidationErrors.
+func CheckCompliance(resource Resource) error {
+	errs := validateResource(resource, "", true, nil)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// resourceCuries returns the CURIE links of a resource, added to the ones of enclosing resources.
+func resourceCuries(resource Resource, inherited map[string]*LinkObject) map[string]*LinkObject {
+	curies := map[string]*LinkObject{}
+
+	for name, link := range inherited {
+		curies[name] = link
+	}
+
+	for _, relation := range linkRelationsOf(resource) {
+		if relation.FullName() != relationtype.CURIES {
+			continue
+		}
+
+		for _, link := range relation.Links() {
+			if link != nil && link.Name != "" {
+				curies[link.Name] = link
+			}
+		}
+	}
+
+	return curies
+}
+
+// checkResource checks a single resource without its embedded resources.
+func checkResource(resource Resource, pointer string, curies map[string]*LinkObject) ValidationErrors {
+	errs := ValidationErrors{}
+
+	for _, name := range []string{LinksProperty, EmbeddedProperty} {
+		if _, ok := resource.Data()[name]; ok {
+			errs = append(errs, &ValidationError{Pointer: pointer + "/" + name, Err: ErrReservedProperty})
+		}
+	}
+
+	linkRelations := []Relation{}
+
+	for _, relation := range linkRelationsOf(resource) {
+		if relation.FullName() == relationtype.CURIES {
+			if !relation.IsLinkSet() {
+				errs = append(errs, &ValidationError{Pointer: pointer + "/" + LinksProperty + "/" + relationtype.CURIES, Err: ErrCuriesNotArray})
+			}
+
+			continue
+		}
+
+		linkRelations = append(linkRelations, relation)
+	}
+
+	resourceRelations := []Relation{}
+
+	for _, relation := range resourceRelationsOf(resource) {
+		resourceRelations = append(resourceRelations, relation)
+	}
+
+	errs = append(errs, checkRelations(linkRelations, pointer+"/"+LinksProperty, curies)...)
+	errs = append(errs, checkRelations(resourceRelations, pointer+"/"+EmbeddedProperty, curies)...)
+
+	return errs
+}
+
+// checkRelations checks CURIE prefixes of relations and relations appearing in two forms.
+func checkRelations(relations []Relation, pointer string, curies map[string]*LinkObject) ValidationErrors {
+	errs := ValidationErrors{}
+	expandedNames := map[string]string{}
+
+	for _, relation := range relations {
+		fullName := relation.FullName()
+		relationPointer := pointer + "/" + escapePointer(fullName)
+		expanded := fullName
+
+		if prefix, reference, ok := compactName(fullName); ok {
+			curie, defined := curies[prefix]
+
+			if !defined {
+				errs = append(errs, &ValidationError{Pointer: relationPointer, Err: ErrUndefinedCurie})
+
+				continue
+			}
+
+			value, err := uritemplate.Expand(curie.Href, map[string]interface{}{"rel": reference})
+
+			if err != nil {
+				continue
+			}
+
+			expanded = value
+		}
+
+		if name, ok := expandedNames[expanded]; ok && name != fullName {
+			errs = append(errs, &ValidationError{Pointer: relationPointer, Err: ErrDuplicateRelation})
+
+			continue
+		}
+
+		expandedNames[expanded] = fullName
+	}
+
+	return errs
+}
+
+// compactName splits a compact CURIE name into prefix and reference.
+func compactName(name string) (string, string, bool) {
+	index := strings.Index(name, ":")
+
+	if index <= 0 || strings.HasPrefix(name[index:], "://") || strings.EqualFold(name[:index], "urn") {
+		return "", "", false
+	}
+
+	return name[:index], name[index+1:], true
+}
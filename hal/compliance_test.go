@@ -0,0 +1,164 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type clashingData struct {
+	Name  string      `json:"name"`
+	Links interface{} `json:"_links"`
+}
+
+func createCompliantResource() Resource {
+	root := NewResourceObject()
+	curie, _ := NewCurieLink("doc", "http://example.com/docs/relations/{rel}")
+	root.AddCurieLinks([]*LinkObject{curie})
+
+	self := NewSelfLinkRelation()
+	self.SetLink(&LinkObject{Href: "/docwhoapi/doctors"})
+	root.AddLink(self)
+
+	companions, _ := NewLinkRelation("companions")
+	companions.SetCurieLink(curie)
+	companions.SetLink(&LinkObject{Href: "/docwhoapi/companions"})
+	root.AddLink(companions)
+
+	uriRelation, _ := NewLinkRelation("http://example.com/relations/enemies")
+	uriRelation.SetLink(&LinkObject{Href: "/docwhoapi/enemies"})
+	root.AddLink(uriRelation)
+
+	// CURIEs are inherited by embedded resources
+	doctor := NewResourceObject()
+	episodes, _ := NewLinkRelation("doc:episodes")
+	episodes.SetLink(&LinkObject{Href: "/docwhoapi/doctors/1/episodes"})
+	doctor.AddLink(episodes)
+	doctors, _ := NewResourceRelation("doctors")
+	doctors.SetResources([]Resource{doctor})
+	root.AddResource(doctors)
+
+	return root
+}
+
+func TestCheckCompliance(t *testing.T) {
+	root := createCompliantResource()
+
+	if err := CheckCompliance(root); err != nil {
+		t.Fatalf("CheckCompliance returns error: %s", err)
+	}
+
+	root.AddData(clashingData{Name: "The Doctor", Links: "none"})
+
+	undefined, _ := NewLinkRelation("wiki:doctors")
+	undefined.SetLink(&LinkObject{Href: "/docwhoapi/wiki"})
+	root.AddLink(undefined)
+
+	expanded, _ := NewLinkRelation("http://example.com/docs/relations/companions")
+	expanded.SetLink(&LinkObject{Href: "/docwhoapi/companions"})
+	root.AddLink(expanded)
+
+	companion := NewResourceObject()
+	curies, _ := NewLinkRelation("curies")
+	curie, _ := NewCurieLink("tardis", "http://example.com/tardis/{rel}")
+	curies.SetLink(curie)
+	companion.AddLink(curies)
+	companions, _ := NewResourceRelation("doc:companions")
+	companions.SetResource(companion)
+	root.AddResource(companions)
+
+	err := CheckCompliance(root)
+	validationErrors := ValidationErrors{}
+
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("Error is %v, want ValidationErrors", err)
+	}
+
+	wanted := []struct {
+		pointer string
+		cause   error
+	}{
+		{"/_links", ErrReservedProperty},
+		{"/_links/wiki:doctors", ErrUndefinedCurie},
+		{"/_links/http:~1~1example.com~1docs~1relations~1companions", ErrDuplicateRelation},
+		{"/_embedded/doc:companions/_links/curies", ErrCuriesNotArray},
+	}
+
+	if len(validationErrors) != len(wanted) {
+		t.Fatalf("Error count %d, want %d: %s", len(validationErrors), len(wanted), err)
+	}
+
+	for i, w := range wanted {
+		if validationErrors[i].Pointer != w.pointer {
+			t.Errorf("Pointer is %s, want %s", validationErrors[i].Pointer, w.pointer)
+		}
+
+		if !errors.Is(validationErrors[i], w.cause) {
+			t.Errorf("Error is %v, want %v", validationErrors[i], w.cause)
+		}
+	}
+}
+
+func TestStrictEncoding(t *testing.T) {
+	root := createCompliantResource()
+	encoder := NewEncoder(WithStrictEncoding())
+
+	value, err := encoder.ToJSON(root)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	wanted, _ := NewEncoder().ToJSON(root)
+
+	if string(value) != string(wanted) {
+		t.Errorf("JSON value == %s, want %s", value, wanted)
+	}
+
+	root.Data()[EmbeddedProperty] = "none"
+
+	if _, err := encoder.ToJSON(root); !errors.Is(err, ErrReservedProperty) {
+		t.Errorf("Error is %v, want %v", err, ErrReservedProperty)
+	}
+
+	buffer := new(bytes.Buffer)
+
+	if err := NewStreamEncoder(buffer, WithStrictEncoding()).Encode(root); !errors.Is(err, ErrReservedProperty) {
+		t.Errorf("Error is %v, want %v", err, ErrReservedProperty)
+	}
+
+	if buffer.Len() > 0 {
+		t.Errorf("Nothing should be written, got %s", buffer)
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	document := `{
+		"_links": {
+			"curies": {"href": "http://example.com/docs/relations/{rel}", "templated": true, "name": "doc"},
+			"doc:companions": {"href": "/docwhoapi/companions"},
+			"doc:enemies": {"href": "/docwhoapi/enemies"},
+			"http://example.com/docs/relations/enemies": {"href": "/docwhoapi/enemies"}
+		}
+	}`
+
+	if _, err := NewDecoder().FromJSON([]byte(document)); err != nil {
+		t.Fatalf("FromJSON returns error: %s", err)
+	}
+
+	_, err := NewDecoder(WithStrictDecoding()).FromJSON([]byte(document))
+
+	if !errors.Is(err, ErrCuriesNotArray) || !errors.Is(err, ErrDuplicateRelation) {
+		t.Errorf("Error is %v, want %v and %v", err, ErrCuriesNotArray, ErrDuplicateRelation)
+	}
+
+	compliant, _ := NewEncoder().ToJSON(createCompliantResource())
+
+	if _, err := NewDecoder(WithStrictDecoding()).FromJSON(compliant); err != nil {
+		t.Errorf("FromJSON returns error: %s", err)
+	}
+}
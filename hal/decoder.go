@@ -0,0 +1,356 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+// Decoder to decode a HAL document into a Resource.
+type Decoder interface {
+	FromJSON(data []byte) (Resource, error)
+}
+
+// DecoderOption configures a Decoder.
+type DecoderOption func(*decoderOptions)
+
+type decoderOptions struct {
+	strict bool
+}
+
+// WithStrictDecoding makes a Decoder check each decoded Resource by CheckCompliance.
+// A HAL document violating the HAL draft is rejected and the ValidationErrors are returned.
+func WithStrictDecoding() DecoderOption {
+	return func(options *decoderOptions) {
+		options.strict = true
+	}
+}
+
+type standardDecoder struct {
+	options decoderOptions
+}
+
+// NewDecoder creates a JSON decoder
+func NewDecoder(options ...DecoderOption) Decoder {
+	decoder := new(standardDecoder)
+
+	for _, option := range options {
+		option(&decoder.options)
+	}
+
+	return decoder
+}
+
+// FromJSON creates a Resource from provided HAL document.
+//
+// Link relations and embedded resources keep their single value or array structure.
+// A relation prefixed with the name of a CURIE link gets this CURIE link assigned. CURIE links
+// are inherited by embedded resources.
+// All other properties are assigned as data in document order. Numbers are kept as json.Number to
+// avoid loss of precision.
+func (dec *standardDecoder) FromJSON(data []byte) (Resource, error) {
+	resource, err := decodeResource(data, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dec.options.strict {
+		if err := CheckCompliance(resource); err != nil {
+			return nil, err
+		}
+	}
+
+	return resource, nil
+}
+
+func decodeResource(data []byte, inheritedCurieLinks map[string]*LinkObject) (Resource, error) {
+	names, properties, err := decodeObject(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resource := NewResourceObject().(*resourceObject)
+	curieLinks := map[string]*LinkObject{}
+
+	for name, curieLink := range inheritedCurieLinks {
+		curieLinks[name] = curieLink
+	}
+
+	if value, ok := properties[LinksProperty]; ok {
+		if err := decodeLinks(resource, value, curieLinks); err != nil {
+			return nil, fmt.Errorf("%s: %w", LinksProperty, err)
+		}
+	}
+
+	if value, ok := properties[EmbeddedProperty]; ok {
+		if err := decodeEmbeddedResources(resource, value, curieLinks); err != nil {
+			return nil, fmt.Errorf("%s: %w", EmbeddedProperty, err)
+		}
+	}
+
+	for _, name := range names {
+		if name == LinksProperty || name == EmbeddedProperty {
+			continue
+		}
+
+		value, err := decodeValue(properties[name])
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		resource.data[name] = value
+		resource.dataNames = append(resource.dataNames, name)
+	}
+
+	return resource, nil
+}
+
+// decodeLinks assigns all link relations of a "_links" object to the resource.
+// The provided CURIE links are extended by the resource's own CURIE links.
+func decodeLinks(resource Resource, data []byte, curieLinks map[string]*LinkObject) error {
+	names, properties, err := decodeObject(data)
+
+	if err != nil {
+		return err
+	}
+
+	if value, ok := properties[relationtype.CURIES]; ok {
+		links, _, err := decodeLinkObjects(value)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", relationtype.CURIES, err)
+		}
+
+		for _, link := range links {
+			if link.Name != "" {
+				curieLinks[link.Name] = link
+			}
+		}
+	}
+
+	for _, name := range names {
+		links, isLinkSet, err := decodeLinkObjects(properties[name])
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		relation, err := newDecodedRelation(name, curieLinks)
+
+		if err != nil {
+			return err
+		}
+
+		if isLinkSet {
+			relation.SetLinks(links)
+		} else if len(links) > 0 {
+			relation.SetLink(links[0])
+		}
+
+		resource.AddLink(relation)
+	}
+
+	return nil
+}
+
+// decodeEmbeddedResources assigns all resource relations of an "_embedded" object to the resource.
+func decodeEmbeddedResources(resource Resource, data []byte, curieLinks map[string]*LinkObject) error {
+	names, properties, err := decodeObject(data)
+
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		value := properties[name]
+		relation, err := newDecodedRelation(name, curieLinks)
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case isJSONNull(value):
+		case isJSONArray(value):
+			var values []json.RawMessage
+
+			if err := json.Unmarshal(value, &values); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			resources := []Resource{}
+
+			for i, v := range values {
+				embedded, err := decodeResource(v, curieLinks)
+
+				if err != nil {
+					return fmt.Errorf("%s[%d]: %w", name, i, err)
+				}
+
+				resources = append(resources, embedded)
+			}
+
+			relation.SetResources(resources)
+		default:
+			embedded, err := decodeResource(value, curieLinks)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			relation.SetResource(embedded)
+		}
+
+		resource.AddResource(relation)
+	}
+
+	return nil
+}
+
+// decodeLinkObjects decodes a single Link Object or an array of Link Objects. The returned
+// flag indicates an array value.
+func decodeLinkObjects(data []byte) ([]*LinkObject, bool, error) {
+	switch {
+	case isJSONNull(data):
+		return []*LinkObject{}, false, nil
+	case isJSONArray(data):
+		links := []*LinkObject{}
+
+		if err := json.Unmarshal(data, &links); err != nil {
+			return nil, false, err
+		}
+
+		for _, link := range links {
+			if link == nil {
+				return nil, false, errors.New("Link Object must not be null")
+			}
+		}
+
+		return links, true, nil
+	case isJSONObject(data):
+		link := new(LinkObject)
+
+		if err := json.Unmarshal(data, link); err != nil {
+			return nil, false, err
+		}
+
+		return []*LinkObject{link}, false, nil
+	}
+
+	return nil, false, errors.New("Link Object must be a JSON object or an array of JSON objects")
+}
+
+// newDecodedRelation creates a relation for a full relation name. If the name is prefixed
+// with the name of a known CURIE link, this CURIE link is assigned.
+func newDecodedRelation(fullName string, curieLinks map[string]*LinkObject) (*linkRelation, error) {
+	if index := strings.Index(fullName, ":"); index > 0 {
+		if curieLink, ok := curieLinks[fullName[:index]]; ok {
+			relation, err := newRelation(fullName[index+1:])
+
+			if err != nil {
+				return nil, err
+			}
+
+			relation.SetCurieLink(curieLink)
+
+			return relation, nil
+		}
+	}
+
+	return newRelation(fullName)
+}
+
+// decodeObject decodes a JSON object and returns its property names in document order.
+func decodeObject(data []byte) ([]string, map[string]json.RawMessage, error) {
+	if !isJSONObject(data) {
+		return nil, nil, errors.New("value must be a JSON object")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	// consume opening brace
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+
+	names := []string{}
+	properties := map[string]json.RawMessage{}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		name := token.(string)
+		var value json.RawMessage
+
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+
+		if _, ok := properties[name]; !ok {
+			names = append(names, name)
+		}
+
+		properties[name] = value
+	}
+
+	// consume closing brace and ensure there is no trailing data
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, nil, errors.New("unexpected data after JSON object")
+	}
+
+	return names, properties, nil
+}
+
+func decodeValue(data []byte) (interface{}, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func isJSONObject(data []byte) bool {
+	return firstJSONByte(data) == '{'
+}
+
+func isJSONArray(data []byte) bool {
+	return firstJSONByte(data) == '['
+}
+
+func isJSONNull(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
+
+func firstJSONByte(data []byte) byte {
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) == 0 {
+		return 0
+	}
+
+	return trimmed[0]
+}
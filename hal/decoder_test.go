@@ -0,0 +1,195 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+const decoderTestDocument = `{
+	"_links": {
+		"self": {"href": "/docwhoapi/doctors"},
+		"curies": [{"href": "http://example.com/docs/relations/{rel}", "templated": true, "name": "doc"}],
+		"doc:companions": {"href": "/docwhoapi/companions", "title": "Companions"},
+		"alternate": [{"href": "/docwhoapi/doctors.xml", "type": "application/xml"}]
+	},
+	"_embedded": {
+		"doc:doctors": [
+			{"_links": {"self": {"href": "/docwhoapi/doctors/1"}}, "name": "William Hartnell"},
+			{"_links": {"self": {"href": "/docwhoapi/doctors/2"}}, "name": "Patrick Troughton"}
+		],
+		"companion": {"name": "Susan Foreman", "_links": {"doc:actor": {"href": "/docwhoapi/actors/1"}}}
+	},
+	"doctorCount": 12,
+	"content": "All actors of the Doctor.",
+	"details": {"from": "1963", "until": null}
+}`
+
+func TestDecoder(t *testing.T) {
+	decoder := NewDecoder()
+	resource, err := decoder.FromJSON([]byte(decoderTestDocument))
+
+	if err != nil {
+		t.Fatalf("FromJSON returns error: %s", err)
+	}
+
+	relations := linkRelationsOf(resource)
+	wantedNames := []string{relationtype.Self, relationtype.CURIES, "doc:companions", "alternate"}
+
+	if count := len(relations); count != len(wantedNames) {
+		t.Fatalf("Link relations count %d, want %d", count, len(wantedNames))
+	}
+
+	for i, relation := range relations {
+		if relation.FullName() != wantedNames[i] {
+			t.Errorf("Link relation name is %s, want %s", relation.FullName(), wantedNames[i])
+		}
+	}
+
+	if relations[0].IsLinkSet() {
+		t.Errorf("Link relation %s should not be a link set", relations[0].FullName())
+	}
+
+	if href := relations[0].Links()[0].Href; href != "/docwhoapi/doctors" {
+		t.Errorf("Self link is %s, want %s", href, "/docwhoapi/doctors")
+	}
+
+	if !relations[1].IsLinkSet() {
+		t.Errorf("Link relation %s should be a link set", relations[1].FullName())
+	}
+
+	if name := relations[2].Name(); name != "companions" {
+		t.Errorf("Link relation name is %s, want %s", name, "companions")
+	}
+
+	if curieLink := relations[2].CurieLink(); curieLink.Href != "http://example.com/docs/relations/{rel}" || !curieLink.Templated {
+		t.Errorf("CURIE link is %+v, want %+v", curieLink, relations[1].Links()[0])
+	}
+
+	if title := relations[2].Links()[0].Title; title != "Companions" {
+		t.Errorf("Link title is %s, want %s", title, "Companions")
+	}
+
+	if !relations[3].IsLinkSet() || relations[3].Links()[0].Type != "application/xml" {
+		t.Errorf("Link relation %s is not decoded as link set", relations[3].FullName())
+	}
+
+	embedded := resourceRelationsOf(resource)
+
+	if count := len(embedded); count != 2 {
+		t.Fatalf("Resource relations count %d, want %d", count, 2)
+	}
+
+	if name := embedded[0].Name(); name != "doctors" {
+		t.Errorf("Resource relation name is %s, want %s", name, "doctors")
+	}
+
+	if !embedded[0].IsResourceSet() || len(embedded[0].Resources()) != 2 {
+		t.Errorf("Resource relation %s is not decoded as resource set", embedded[0].FullName())
+	}
+
+	if name := embedded[0].Resources()[1].Data()["name"]; name != "Patrick Troughton" {
+		t.Errorf("Embedded resource name is %s, want %s", name, "Patrick Troughton")
+	}
+
+	if embedded[1].IsResourceSet() || len(embedded[1].Resources()) != 1 {
+		t.Errorf("Resource relation %s should not be a resource set", embedded[1].FullName())
+	}
+
+	companion := embedded[1].Resources()[0]
+
+	if name := linkRelationsOf(companion)[0].FullName(); name != "doc:actor" {
+		t.Errorf("Inherited CURIE link is not assigned: %s", name)
+	}
+
+	data := resource.Data()
+
+	if count := len(data); count != 3 {
+		t.Errorf("Data amount %d, want %d", count, 3)
+	}
+
+	if value := data["doctorCount"]; value != json.Number("12") {
+		t.Errorf("Value is %v, want %v", value, json.Number("12"))
+	}
+
+	wantedDetails := map[string]interface{}{"from": "1963", "until": nil}
+
+	if value := data["details"]; !reflect.DeepEqual(value, wantedDetails) {
+		t.Errorf("Value is %v, want %v", value, wantedDetails)
+	}
+}
+
+func TestDecoderRoundTrip(t *testing.T) {
+	decoder := NewDecoder()
+	encoder := NewEncoder()
+
+	resource, err := decoder.FromJSON([]byte(decoderTestDocument))
+
+	if err != nil {
+		t.Fatalf("FromJSON returns error: %s", err)
+	}
+
+	bytes, err := encoder.ToJSON(resource)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	var wanted, decoded interface{}
+	_ = json.Unmarshal([]byte(decoderTestDocument), &wanted)
+	_ = json.Unmarshal(bytes, &decoded)
+
+	if !reflect.DeepEqual(decoded, wanted) {
+		t.Errorf("JSON value == %s, want %s", string(bytes), decoderTestDocument)
+	}
+}
+
+func TestDecoderWithNullRelations(t *testing.T) {
+	decoder := NewDecoder()
+	resource, err := decoder.FromJSON([]byte(`{"_links": {"next": null}, "_embedded": {"items": null}}`))
+
+	if err != nil {
+		t.Fatalf("FromJSON returns error: %s", err)
+	}
+
+	if links := linkRelationsOf(resource); len(links) != 1 || len(links[0].Links()) != 0 {
+		t.Errorf("Link relation should exist without links: %v", links)
+	}
+
+	if resources := resourceRelationsOf(resource); len(resources) != 1 || len(resources[0].Resources()) != 0 {
+		t.Errorf("Resource relation should exist without resources: %v", resources)
+	}
+}
+
+func TestDecoderWithInvalidDocuments(t *testing.T) {
+	documents := []string{
+		``,
+		`null`,
+		`[]`,
+		`"test"`,
+		`{"a": 1`,
+		`{"a": 1} {}`,
+		`{"_links": []}`,
+		`{"_links": {"self": "/docwhoapi/doctors"}}`,
+		`{"_links": {"self": [null]}}`,
+		`{"_links": {"": {"href": "/docwhoapi/doctors"}}}`,
+		`{"_links": {"curies": 1}}`,
+		`{"_embedded": {"doctors": "test"}}`,
+		`{"_embedded": {"doctors": [1]}}`,
+		`{"_embedded": {"doctors": {"_links": []}}}`,
+	}
+
+	decoder := NewDecoder()
+
+	for _, document := range documents {
+		if _, err := decoder.FromJSON([]byte(document)); err == nil {
+			t.Errorf("FromJSON should return an error for %q", document)
+		}
+	}
+}
@@ -5,7 +5,11 @@
 package hal
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"io"
+	"net/url"
 )
 
 // Encoder to encode a Resource into a valid HAL document.
@@ -13,17 +17,157 @@ type Encoder interface {
 	ToJSON(resource Resource) ([]byte, error)
 }
 
+// StreamEncoder to encode a Resource into a valid HAL document written to an io.Writer.
+type StreamEncoder interface {
+	Encode(resource Resource) error
+}
+
+// EncoderOption configures an Encoder or a StreamEncoder.
+type EncoderOption func(*encoderOptions)
+
+type encoderOptions struct {
+	orderedProperties bool
+	indented          bool
+	prefix            string
+	indent            string
+	disableHTMLEscape bool
+	trailingNewline   bool
+	strict            bool
+	baseURL           *url.URL
+}
+
+// WithOrderedProperties makes an Encoder write properties in a defined order instead of
+// sorting them by name. "_links" is written first, followed by "_embedded" and all data properties.
+// Link relations and embedded resources keep the order they were added. Data properties assigned
+// by AddData keep their field declaration order, data properties of a decoded Resource keep their
+// document order. All other data properties follow in sorted order. Embedded resources are written
+// the same way. The order applies to the data properties of a Resource only, nested objects are
+// written sorted by name the same way json.Marshal writes maps.
+func WithOrderedProperties() EncoderOption {
+	return func(options *encoderOptions) {
+		options.orderedProperties = true
+	}
+}
+
+// WithIndent makes an Encoder write indented JSON the same way json.MarshalIndent does.
+func WithIndent(prefix string, indent string) EncoderOption {
+	return func(options *encoderOptions) {
+		options.indented = true
+		options.prefix = prefix
+		options.indent = indent
+	}
+}
+
+// WithEscapeHTML specifies whether problematic HTML characters should be escaped inside
+// JSON quoted strings. The default is true.
+func WithEscapeHTML(escape bool) EncoderOption {
+	return func(options *encoderOptions) {
+		options.disableHTMLEscape = !escape
+	}
+}
+
+// WithTrailingNewline makes an Encoder terminate each HAL document with a newline.
+func WithTrailingNewline() EncoderOption {
+	return func(options *encoderOptions) {
+		options.trailingNewline = true
+	}
+}
+
+// WithStrictEncoding makes an Encoder check each Resource by CheckCompliance before encoding.
+// A Resource violating the HAL draft is not encoded and the ValidationErrors are returned.
+func WithStrictEncoding() EncoderOption {
+	return func(options *encoderOptions) {
+		options.strict = true
+	}
+}
+
+// WithBaseURL makes an Encoder resolve the href of each LinkObject against base, CURIE links and
+// links of embedded resources included. URI Template expressions are kept unchanged.
+// The encoded Resource is not modified, use ResolveHrefs or RelativizeHrefs to change hrefs in place.
+func WithBaseURL(base *url.URL) EncoderOption {
+	return func(options *encoderOptions) {
+		options.baseURL = base
+	}
+}
+
+func newEncoderOptions(options []EncoderOption) encoderOptions {
+	result := encoderOptions{}
+
+	for _, option := range options {
+		option(&result)
+	}
+
+	return result
+}
+
 type standardEncoder struct {
+	options encoderOptions
 }
 
 // NewEncoder creates a JSON encoder
-func NewEncoder() Encoder {
-	return new(standardEncoder)
+func NewEncoder(options ...EncoderOption) Encoder {
+	return &standardEncoder{options: newEncoderOptions(options)}
 }
 
 // ToJSON generates a HAL document from provided Resource.
 func (enc *standardEncoder) ToJSON(resource Resource) ([]byte, error) {
-	namedMap := resource.ToMap()
+	if enc.options.strict {
+		if err := CheckCompliance(resource); err != nil {
+			return nil, err
+		}
+	}
+
+	if enc.options == (encoderOptions{strict: enc.options.strict}) {
+		namedMap := resource.ToMap()
+
+		return json.Marshal(namedMap.Content)
+	}
+
+	buffer := new(bytes.Buffer)
+
+	if err := encode(buffer, resource, enc.options); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+type streamEncoder struct {
+	w       io.Writer
+	options encoderOptions
+}
+
+// NewStreamEncoder creates a JSON encoder writing to w.
+// Links, embedded resources and data are written one after the other without
+// creating the complete Resource.ToMap() tree first.
+func NewStreamEncoder(w io.Writer, options ...EncoderOption) StreamEncoder {
+	return &streamEncoder{w: w, options: newEncoderOptions(options)}
+}
+
+// Encode writes the HAL document of provided Resource.
+func (enc *streamEncoder) Encode(resource Resource) error {
+	if enc.options.strict {
+		if err := CheckCompliance(resource); err != nil {
+			return err
+		}
+	}
+
+	buffered := bufio.NewWriter(enc.w)
+
+	if err := encode(buffered, resource, enc.options); err != nil {
+		return err
+	}
+
+	return buffered.Flush()
+}
+
+func encode(w io.Writer, resource Resource, options encoderOptions) error {
+	writer := &jsonWriter{w: w, options: options}
+	writer.writeResource(resource)
+
+	if options.trailingNewline {
+		writer.write("\n")
+	}
 
-	return json.Marshal(namedMap.Content)
+	return writer.err
 }
@@ -5,8 +5,12 @@
 package hal
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/pmoule/go2hal/hal/relationtype"
@@ -152,3 +156,234 @@ func TestEncoderWithEmbeddedResources(t *testing.T) {
 		t.Errorf("Generated JSON does not contain expected doctor: %s", actors[1].Name)
 	}
 }
+
+type orderedDoctor struct {
+	Name   string `json:"name"`
+	Actor  string `json:"actor"`
+	Number int    `json:"number"`
+}
+
+func createOrderedTestResource() Resource {
+	root := NewResourceObject()
+	self, _ := NewLinkRelation(relationtype.Self)
+	self.SetLink(&LinkObject{Href: "/docwhoapi/doctors"})
+	root.AddLink(self)
+
+	curieLink, _ := NewCurieLink("doc", "http://example.com/docs/relations/{rel}")
+	root.AddCurieLinks([]*LinkObject{curieLink})
+
+	companions, _ := NewLinkRelation("companions")
+	companions.SetCurieLink(curieLink)
+	companions.SetLinks([]*LinkObject{{Href: "/docwhoapi/companions"}})
+	root.AddLink(companions)
+
+	embedded := NewResourceObject()
+	embeddedSelf := NewSelfLinkRelation()
+	embeddedSelf.SetLink(&LinkObject{Href: "/docwhoapi/doctors/1"})
+	embedded.AddLink(embeddedSelf)
+	embedded.AddData(orderedDoctor{"The Doctor", "William Hartnell", 1})
+
+	doctors, _ := NewResourceRelation("doctors")
+	doctors.SetResources([]Resource{embedded})
+	root.AddResource(doctors)
+
+	first, _ := NewResourceRelation("first")
+	first.SetResource(embedded)
+	root.AddResource(first)
+
+	root.Data()["zcount"] = 1
+	root.Data()["content"] = "All actors of the Doctor."
+	root.AddData(struct {
+		Until string `json:"until"`
+		From  string `json:"from"`
+	}{"today", "1963"})
+
+	return root
+}
+
+func TestEncoderWithOrderedProperties(t *testing.T) {
+	doctor := `{"_links":{"self":{"href":"/docwhoapi/doctors/1"}},"name":"The Doctor","actor":"William Hartnell","number":1}`
+	wanted := `{"_links":{"self":{"href":"/docwhoapi/doctors"},` +
+		`"curies":[{"href":"http://example.com/docs/relations/{rel}","templated":true,"name":"doc"}],` +
+		`"doc:companions":[{"href":"/docwhoapi/companions"}]},` +
+		`"_embedded":{"doctors":[` + doctor + `],"first":` + doctor + `},` +
+		`"until":"today","from":"1963","content":"All actors of the Doctor.","zcount":1}`
+
+	encoder := NewEncoder(WithOrderedProperties())
+
+	for i := 0; i < 10; i++ {
+		bytes, err := encoder.ToJSON(createOrderedTestResource())
+
+		if err != nil {
+			t.Fatalf("ToJSON returns error: %s", err)
+		}
+
+		if value := string(bytes); value != wanted {
+			t.Fatalf("JSON value == %s, want %s", value, wanted)
+		}
+	}
+}
+
+func TestEncoderWithOrderedNestedProperties(t *testing.T) {
+	document := `{"_links":{"self":{"href":"/docwhoapi/doctors/1"}},"name":"The Doctor",` +
+		`"actor":{"name":"William Hartnell","born":1908},"number":1}`
+	// nested objects are sorted by name
+	wanted := `{"_links":{"self":{"href":"/docwhoapi/doctors/1"}},"name":"The Doctor",` +
+		`"actor":{"born":1908,"name":"William Hartnell"},"number":1}`
+
+	resource, err := NewDecoder().FromJSON([]byte(document))
+
+	if err != nil {
+		t.Fatalf("FromJSON returns error: %s", err)
+	}
+
+	bytes, err := NewEncoder(WithOrderedProperties()).ToJSON(resource)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("JSON value == %s, want %s", value, wanted)
+	}
+
+	root := NewResourceObject()
+	root.AddData(struct {
+		Name  string        `json:"name"`
+		Actor orderedDoctor `json:"actor"`
+	}{"The Doctor", orderedDoctor{"The Doctor", "William Hartnell", 1}})
+	wanted = `{"name":"The Doctor","actor":{"actor":"William Hartnell","name":"The Doctor","number":1}}`
+
+	bytes, err = NewEncoder(WithOrderedProperties()).ToJSON(root)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("JSON value == %s, want %s", value, wanted)
+	}
+}
+
+func TestJSONWriterMatchesToMap(t *testing.T) {
+	resource := createOrderedTestResource()
+	resource.Data()[LinksProperty] = "replaced"
+	wanted, _ := json.Marshal(resource.ToMap().Content)
+
+	buffer := new(bytes.Buffer)
+	writer := &jsonWriter{w: buffer}
+	writer.writeResource(resource)
+
+	if writer.err != nil {
+		t.Fatalf("writeResource returns error: %s", writer.err)
+	}
+
+	if value := buffer.String(); value != string(wanted) {
+		t.Errorf("JSON value == %s, want %s", value, string(wanted))
+	}
+}
+
+func TestStreamEncoder(t *testing.T) {
+	resource := createOrderedTestResource()
+	resource.Data()["html"] = "<b>Doctor Who</b>"
+	wanted, _ := json.Marshal(resource.ToMap().Content)
+
+	buffer := new(bytes.Buffer)
+	encoder := NewStreamEncoder(buffer)
+
+	if err := encoder.Encode(resource); err != nil {
+		t.Fatalf("Encode returns error: %s", err)
+	}
+
+	if value := buffer.String(); value != string(wanted) {
+		t.Errorf("JSON value == %s, want %s", value, string(wanted))
+	}
+
+	wanted, _ = json.MarshalIndent(resource.ToMap().Content, ">", "\t")
+	buffer.Reset()
+	encoder = NewStreamEncoder(buffer, WithIndent(">", "\t"), WithTrailingNewline())
+
+	if err := encoder.Encode(resource); err != nil {
+		t.Fatalf("Encode returns error: %s", err)
+	}
+
+	if value := buffer.String(); value != string(wanted)+"\n" {
+		t.Errorf("JSON value == %s, want %s", value, string(wanted))
+	}
+
+	buffer.Reset()
+	encoder = NewStreamEncoder(buffer, WithEscapeHTML(false))
+
+	if err := encoder.Encode(resource); err != nil {
+		t.Fatalf("Encode returns error: %s", err)
+	}
+
+	if value := buffer.String(); !strings.Contains(value, `"html":"<b>Doctor Who</b>"`) {
+		t.Errorf("JSON value == %s, want unescaped HTML", value)
+	}
+}
+
+func TestEncoderWithOptions(t *testing.T) {
+	resource := createOrderedTestResource()
+	wanted, _ := json.MarshalIndent(resource.ToMap().Content, "", "  ")
+
+	bytes, err := NewEncoder(WithIndent("", "  ")).ToJSON(resource)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	if value := string(bytes); value != string(wanted) {
+		t.Errorf("JSON value == %s, want %s", value, string(wanted))
+	}
+
+	resource.Data()["invalid"] = func() {}
+
+	if _, err := NewEncoder(WithTrailingNewline()).ToJSON(resource); err == nil {
+		t.Errorf("ToJSON should return an error due to an unsupported value.")
+	}
+}
+
+type failingWriter struct{}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestStreamEncoderWithFailingWriter(t *testing.T) {
+	encoder := NewStreamEncoder(failingWriter{})
+
+	if err := encoder.Encode(createOrderedTestResource()); err == nil {
+		t.Errorf("Encode should return an error due to a failing writer.")
+	}
+}
+
+func TestEncoderWithBaseURL(t *testing.T) {
+	root, curieLink := newHrefTestResource()
+	base, _ := url.Parse("https://example.com/api/")
+
+	bytes, err := NewEncoder(WithBaseURL(base), WithOrderedProperties()).ToJSON(root)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	wanted := `{"_links":{"self":{"href":"https://example.com/api/doctors"},` +
+		`"curies":[{"href":"https://example.com/docs/{rel}","templated":true,"name":"doc"}],` +
+		`"doc:search":{"href":"https://example.com/api/doctors{?name}","templated":true}},` +
+		`"_embedded":{"doc:doctors":{"_links":{"self":{"href":"https://example.com/api/doctors/1"}}}}}`
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("JSON value == %s, want %s", value, wanted)
+	}
+
+	if curieLink.Href != "/docs/{rel}" {
+		t.Errorf("Encoding should not change href %s", curieLink.Href)
+	}
+
+	linkRelationsOf(root)[0].Links()[0].Href = "http://[::1"
+
+	if _, err := NewEncoder(WithBaseURL(base)).ToJSON(root); !errors.Is(err, ErrInvalidURIRef) {
+		t.Errorf("Error is %v, want %v", err, ErrInvalidURIRef)
+	}
+}
@@ -0,0 +1,427 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+	"github.com/pmoule/go2hal/hal/uritemplate"
+)
+
+// FactoryOption configures a Factory.
+type FactoryOption func(*factoryOptions)
+
+type factoryOptions struct {
+	baseHref       string
+	linkType       string
+	registry       *relationtype.Registry
+	curieLinks     []*LinkObject
+	arrayRelations []string
+	hrefResolver   func(href string) (string, error)
+}
+
+// WithBaseHref makes a Factory resolve relative hrefs against provided absolute URL.
+// URI Template expressions of an href are kept unchanged.
+func WithBaseHref(baseHref string) FactoryOption {
+	return func(options *factoryOptions) {
+		options.baseHref = baseHref
+	}
+}
+
+// WithDefaultLinkType makes a Factory set the Type of all created links, self links included,
+// to provided media type. Links created with OfType keep their own type.
+func WithDefaultLinkType(mediaType string) FactoryOption {
+	return func(options *factoryOptions) {
+		options.linkType = mediaType
+	}
+}
+
+// WithCurieRegistry makes a Factory check relations against a relationtype.Registry and add the
+// registry's CURIE namespaces to root resources. Unknown relation types, unknown CURIEs and relation
+// types used the wrong way are reported as error.
+func WithCurieRegistry(registry *relationtype.Registry) FactoryOption {
+	return func(options *factoryOptions) {
+		options.registry = registry
+	}
+}
+
+// WithCurieLinks adds CURIE links to a Factory. They are added to root resources and picked
+// by the prefix of compact relation names, e.g. doc:doctors.
+func WithCurieLinks(curieLinks ...*LinkObject) FactoryOption {
+	return func(options *factoryOptions) {
+		options.curieLinks = append(options.curieLinks, curieLinks...)
+	}
+}
+
+// WithArrayRelations makes a Factory always structure links and embedded resources of provided
+// relations in an array. Relations are identified by their full name, e.g. doc:doctors.
+func WithArrayRelations(relationNames ...string) FactoryOption {
+	return func(options *factoryOptions) {
+		options.arrayRelations = append(options.arrayRelations, relationNames...)
+	}
+}
+
+// WithHrefResolver makes a Factory pass each href of a created link, self links included, to
+// resolve. The returned href is used instead. With WithBaseHref, hrefs are resolved against the
+// base href first. See halhttp.LinkBuilder for resolving hrefs of requests behind reverse proxies.
+func WithHrefResolver(resolve func(href string) (string, error)) FactoryOption {
+	return func(options *factoryOptions) {
+		options.hrefResolver = resolve
+	}
+}
+
+// LinkOption configures a LinkObject created by a Factory.
+type LinkOption func(*LinkObject) error
+
+// Titled sets the Title of a link.
+func Titled(title string) LinkOption {
+	return func(link *LinkObject) error {
+		link.Title = title
+		return nil
+	}
+}
+
+// OfType sets the Type of a link, which must be a valid media type.
+func OfType(mediaType string) LinkOption {
+	return func(link *LinkObject) error {
+		if !isMediaType(mediaType) {
+			return &LinkError{Property: "type", Value: mediaType, Err: ErrInvalidMediaType}
+		}
+
+		link.Type = mediaType
+		return nil
+	}
+}
+
+// Templated marks the href of a link as URI Template. The href must be a valid URI Template.
+// See https://tools.ietf.org/html/rfc6570.
+func Templated() LinkOption {
+	return func(link *LinkObject) error {
+		if _, err := uritemplate.Parse(link.Href); err != nil {
+			return err
+		}
+
+		link.Templated = true
+		return nil
+	}
+}
+
+// Deprecated marks a link as deprecated. The URL should provide further information about
+// the deprecation.
+func Deprecated(deprecationURL string) LinkOption {
+	return func(link *LinkObject) error {
+		if !isAbsoluteURI(deprecationURL) {
+			return &LinkError{Property: "deprecation", Value: deprecationURL, Err: ErrInvalidURI}
+		}
+
+		link.Deprecation = deprecationURL
+		return nil
+	}
+}
+
+// Factory creates resources and links. Unlike ResourceFactory, a Factory reports invalid hrefs,
+// relation names and CURIEs as error.
+type Factory struct {
+	options        factoryOptions
+	base           *url.URL
+	curieLinks     map[string]*LinkObject
+	curieNames     []string
+	arrayRelations map[string]bool
+}
+
+// NewFactory creates a Factory configured by provided options.
+func NewFactory(options ...FactoryOption) (*Factory, error) {
+	factory := &Factory{curieLinks: map[string]*LinkObject{}, arrayRelations: map[string]bool{}}
+
+	for _, option := range options {
+		option(&factory.options)
+	}
+
+	if factory.options.baseHref != "" {
+		base, err := url.Parse(factory.options.baseHref)
+
+		if err != nil {
+			return nil, fmt.Errorf("base href: %w", err)
+		}
+
+		if !base.IsAbs() {
+			return nil, fmt.Errorf("base href %s must be an absolute URL", factory.options.baseHref)
+		}
+
+		factory.base = base
+	}
+
+	if factory.options.linkType != "" && !isMediaType(factory.options.linkType) {
+		return nil, &LinkError{Property: "type", Value: factory.options.linkType, Err: ErrInvalidMediaType}
+	}
+
+	if factory.options.registry != nil {
+		curieLinks, err := NewCurieLinks(factory.options.registry)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, curieLink := range curieLinks {
+			factory.addCurieLink(curieLink)
+		}
+	}
+
+	for _, curieLink := range factory.options.curieLinks {
+		if curieLink == nil || curieLink.Name == "" {
+			return nil, errors.New("CURIE LinkObject requires a name value")
+		}
+
+		factory.addCurieLink(curieLink)
+	}
+
+	for _, name := range factory.options.arrayRelations {
+		factory.arrayRelations[name] = true
+	}
+
+	return factory, nil
+}
+
+// ResourceFactory returns a ResourceFactory creating resources and links the same way the
+// Factory does. It is meant for code still depending on the ResourceFactory interface.
+func (f *Factory) ResourceFactory() ResourceFactoryE {
+	return &resourceFactory{factory: f}
+}
+
+// RootResource creates a root Resource with self link from provided href.
+// Additionally all CURIE links of the Factory are added.
+func (f *Factory) RootResource(href string) (Resource, error) {
+	resource, err := f.EmbeddedResource(href)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(f.curieNames) > 0 {
+		resource.AddCurieLinks(f.CurieLinks())
+	}
+
+	return resource, nil
+}
+
+// EmbeddedResource creates an embedded Resource with self link from provided href.
+func (f *Factory) EmbeddedResource(href string) (Resource, error) {
+	selfLink, err := f.NewLink(href)
+
+	if err != nil {
+		return nil, fmt.Errorf("self link: %w", err)
+	}
+
+	self := NewSelfLinkRelation()
+	self.SetLink(selfLink)
+
+	resource := NewResourceObject()
+	resource.AddLink(self)
+
+	return resource, nil
+}
+
+// CurieLinks returns the CURIE links of the Factory in the order they were added.
+func (f *Factory) CurieLinks() []*LinkObject {
+	curieLinks := make([]*LinkObject, 0, len(f.curieNames))
+
+	for _, name := range f.curieNames {
+		curieLinks = append(curieLinks, f.curieLinks[name])
+	}
+
+	return curieLinks
+}
+
+// NewLink creates a LinkObject with provided href configured by provided options. An href
+// containing URI Template expressions like /orders/{id} is marked as templated.
+func (f *Factory) NewLink(href string, options ...LinkOption) (*LinkObject, error) {
+	if href == "" {
+		return nil, ErrMissingHref
+	}
+
+	resolved, err := f.resolveHref(href)
+
+	if err != nil {
+		return nil, err
+	}
+
+	link := &LinkObject{Href: resolved, Type: f.options.linkType}
+
+	// hrefs with URI Template expressions, e.g. open route parameters, are templated
+	if template, err := uritemplate.Parse(resolved); err == nil && len(template.Variables()) > 0 {
+		link.Templated = true
+	}
+
+	for _, option := range options {
+		if err := option(link); err != nil {
+			return nil, err
+		}
+	}
+
+	return link, nil
+}
+
+// Link creates a Link Relation with a single link from provided href configured by provided options.
+// A compact relation name like doc:doctors gets the CURIE link of the Factory with the name doc.
+func (f *Factory) Link(relationName string, href string, options ...LinkOption) (LinkRelation, error) {
+	link, err := f.NewLink(href, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return f.LinkSet(relationName, link)
+}
+
+// LinkSet creates a Link Relation with provided links. A single link is structured as a single
+// value unless the relation is configured by WithArrayRelations.
+func (f *Factory) LinkSet(relationName string, links ...*LinkObject) (LinkRelation, error) {
+	return f.linkRelation(relationName, "", links)
+}
+
+// ResourceLink creates a Resource Relation with provided embedded resources. A single resource is
+// structured as a single value unless the relation is configured by WithArrayRelations.
+// A compact relation name like doc:doctors gets the CURIE link of the Factory with the name doc.
+func (f *Factory) ResourceLink(relationName string, resources ...Resource) (ResourceRelation, error) {
+	return f.resourceRelation(relationName, "", resources)
+}
+
+func (f *Factory) linkRelation(relationName string, curieLinkName string, links []*LinkObject) (LinkRelation, error) {
+	name, curieLink, err := f.resolveRelation(relationName, curieLinkName, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	relation, err := NewLinkRelation(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if curieLink != nil {
+		relation.SetCurieLink(curieLink)
+	}
+
+	if len(links) == 1 && !f.arrayRelations[relation.FullName()] {
+		relation.SetLink(links[0])
+	} else if len(links) > 0 || f.arrayRelations[relation.FullName()] {
+		relation.SetLinks(links)
+	}
+
+	return relation, nil
+}
+
+func (f *Factory) resourceRelation(relationName string, curieLinkName string, resources []Resource) (ResourceRelation, error) {
+	name, curieLink, err := f.resolveRelation(relationName, curieLinkName, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	relation, err := NewResourceRelation(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if curieLink != nil {
+		relation.SetCurieLink(curieLink)
+	}
+
+	if len(resources) == 1 && !f.arrayRelations[relation.FullName()] {
+		relation.SetResource(resources[0])
+	} else if len(resources) > 0 || f.arrayRelations[relation.FullName()] {
+		relation.SetResources(resources)
+	}
+
+	return relation, nil
+}
+
+func (f *Factory) addCurieLink(link *LinkObject) {
+	if _, ok := f.curieLinks[link.Name]; !ok {
+		f.curieNames = append(f.curieNames, link.Name)
+	}
+
+	f.curieLinks[link.Name] = link
+}
+
+// resolveRelation returns the relation name and CURIE link of a relation. Compact names with the
+// prefix of a known CURIE link are split. With registry, the relation type must be known and
+// allowed for links or embedded resources.
+func (f *Factory) resolveRelation(relationName string, curieLinkName string, embedded bool) (string, *LinkObject, error) {
+	registry := f.options.registry
+
+	if curieLinkName == "" {
+		if definition, ok := f.lookup(relationName); ok {
+			relationName, curieLinkName = definition.Name, definition.Curie
+		} else if prefix, reference, ok := strings.Cut(relationName, ":"); ok && f.curieLinks[prefix] != nil && reference != "" {
+			relationName, curieLinkName = reference, prefix
+		}
+	}
+
+	if registry != nil {
+		fullName := relationName
+
+		if curieLinkName != "" {
+			fullName = curieLinkName + ":" + relationName
+		}
+
+		resolve := registry.ResolveLink
+
+		if embedded {
+			resolve = registry.ResolveEmbedded
+		}
+
+		if _, err := resolve(fullName); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if curieLinkName == "" {
+		return relationName, nil, nil
+	}
+
+	curieLink, ok := f.curieLinks[curieLinkName]
+
+	if !ok {
+		return "", nil, fmt.Errorf("%w %s", relationtype.ErrUnknownCurie, curieLinkName)
+	}
+
+	return relationName, curieLink, nil
+}
+
+// lookup returns the registered definition of compact names and expanded URIs.
+func (f *Factory) lookup(relationName string) (relationtype.Definition, bool) {
+	if f.options.registry == nil {
+		return relationtype.Definition{}, false
+	}
+
+	return f.options.registry.Lookup(relationName)
+}
+
+// resolveHref resolves a relative href against the base href of the Factory and passes it to
+// the href resolver.
+func (f *Factory) resolveHref(href string) (string, error) {
+	if f.base != nil {
+		resolved, err := ResolveHref(f.base, href)
+
+		if err != nil {
+			return "", err
+		}
+
+		href = resolved
+	}
+
+	if f.options.hrefResolver != nil {
+		return f.options.hrefResolver(href)
+	}
+
+	return href, nil
+}
@@ -0,0 +1,292 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+func TestNewFactory(t *testing.T) {
+	invalid := [][]FactoryOption{
+		{WithBaseHref("/relative")},
+		{WithBaseHref("http://[::1")},
+		{WithDefaultLinkType("json")},
+		{WithCurieLinks(&LinkObject{Href: "/docs/{rel}"})},
+	}
+
+	for _, options := range invalid {
+		if _, err := NewFactory(options...); err == nil {
+			t.Errorf("NewFactory should return error")
+		}
+	}
+
+	factory, err := NewFactory()
+
+	if err != nil {
+		t.Fatalf("NewFactory returned error: %v", err)
+	}
+
+	if len(factory.CurieLinks()) != 0 {
+		t.Errorf("CURIE links count is %d, want %d", len(factory.CurieLinks()), 0)
+	}
+}
+
+func TestFactoryResources(t *testing.T) {
+	curieLink, _ := NewCurieLink("doc", "http://example.com/docs/{rel}")
+	factory, _ := NewFactory(WithCurieLinks(curieLink), WithDefaultLinkType("application/hal+json"))
+
+	if _, err := factory.RootResource(""); !errors.Is(err, ErrMissingHref) {
+		t.Errorf("Error is %v, want %v", err, ErrMissingHref)
+	}
+
+	if _, err := factory.EmbeddedResource(""); !errors.Is(err, ErrMissingHref) {
+		t.Errorf("Error is %v, want %v", err, ErrMissingHref)
+	}
+
+	root, err := factory.RootResource("/doctors")
+
+	if err != nil {
+		t.Fatalf("RootResource returned error: %v", err)
+	}
+
+	self := root.Links().Content[relationtype.Self].(*LinkObject)
+
+	if self.Href != "/doctors" {
+		t.Errorf("Self href is %s, want %s", self.Href, "/doctors")
+	}
+
+	if self.Type != "application/hal+json" {
+		t.Errorf("Self type is %s, want %s", self.Type, "application/hal+json")
+	}
+
+	curies := root.Links().Content[relationtype.CURIES].([]*LinkObject)
+
+	if len(curies) != 1 || curies[0] != curieLink {
+		t.Errorf("CURIE links are %v, want %v", curies, curieLink)
+	}
+
+	embedded, _ := factory.EmbeddedResource("/doctors/1")
+
+	if embedded.Links().Content[relationtype.CURIES] != nil {
+		t.Errorf("Embedded resource should not have CURIE links")
+	}
+}
+
+func TestFactoryLink(t *testing.T) {
+	curieLink, _ := NewCurieLink("doc", "http://example.com/docs/{rel}")
+	factory, _ := NewFactory(WithCurieLinks(curieLink), WithBaseHref("http://example.com/api/"))
+
+	link, err := factory.Link("doc:search", "doctors{?name}", Titled("Search"), OfType("application/hal+json"),
+		Templated(), Deprecated("http://example.com/deprecation"))
+
+	if err != nil {
+		t.Fatalf("Link returned error: %v", err)
+	}
+
+	if link.FullName() != "doc:search" || link.CurieLink().Name != "doc" {
+		t.Errorf("Full name is %s, want %s", link.FullName(), "doc:search")
+	}
+
+	want := LinkObject{
+		Href:        "http://example.com/api/doctors{?name}",
+		Templated:   true,
+		Type:        "application/hal+json",
+		Deprecation: "http://example.com/deprecation",
+		Title:       "Search",
+	}
+
+	if *link.Links()[0] != want {
+		t.Errorf("Link is %v, want %v", *link.Links()[0], want)
+	}
+
+	if link.IsLinkSet() {
+		t.Errorf("Link should not be structured in an array")
+	}
+
+	errorTests := []struct {
+		relationName string
+		href         string
+		options      []LinkOption
+		err          error
+	}{
+		{"next", "", nil, ErrMissingHref},
+		{"next", "/", []LinkOption{OfType("json")}, ErrInvalidMediaType},
+		{"next", "/", []LinkOption{Deprecated("deprecated")}, ErrInvalidURI},
+		{"next", "/{x", []LinkOption{Templated()}, nil},
+		{"", "/", nil, nil},
+	}
+
+	for _, test := range errorTests {
+		_, err := factory.Link(test.relationName, test.href, test.options...)
+
+		if err == nil || (test.err != nil && !errors.Is(err, test.err)) {
+			t.Errorf("Link(%q, %q) error is %v, want %v", test.relationName, test.href, err, test.err)
+		}
+	}
+}
+
+func TestFactoryArrayRelations(t *testing.T) {
+	factory, _ := NewFactory(WithArrayRelations("doctors", relationtype.Item))
+
+	link, _ := factory.Link(relationtype.Item, "/doctors/1")
+
+	if !link.IsLinkSet() {
+		t.Errorf("Link should be structured in an array")
+	}
+
+	empty, _ := factory.LinkSet(relationtype.Item)
+
+	if !empty.IsLinkSet() || len(empty.Links()) != 0 {
+		t.Errorf("Empty link set should be structured in an array")
+	}
+
+	doctor, _ := factory.EmbeddedResource("/doctors/1")
+	resources, _ := factory.ResourceLink("doctors", doctor)
+
+	if !resources.IsResourceSet() || len(resources.Resources()) != 1 {
+		t.Errorf("Resources should be structured in an array")
+	}
+
+	companion, _ := factory.ResourceLink("companion", doctor)
+
+	if companion.IsResourceSet() {
+		t.Errorf("Resource should not be structured in an array")
+	}
+}
+
+func TestFactoryWithCurieRegistry(t *testing.T) {
+	registry := relationtype.NewRegistry()
+	registry.RegisterCurie("doc", "http://example.com/docs/{rel}")
+	registry.Register(relationtype.Definition{Name: "doctors", Curie: "doc", Usage: relationtype.EmbeddedOnly})
+
+	factory, err := NewFactory(WithCurieRegistry(registry), WithArrayRelations("doc:doctors"))
+
+	if err != nil {
+		t.Fatalf("NewFactory returned error: %v", err)
+	}
+
+	resources, err := factory.ResourceLink("http://example.com/docs/doctors")
+
+	if err != nil {
+		t.Fatalf("ResourceLink returned error: %v", err)
+	}
+
+	if resources.FullName() != "doc:doctors" || !resources.IsResourceSet() {
+		t.Errorf("Full name is %s, want %s in an array", resources.FullName(), "doc:doctors")
+	}
+
+	if _, err := factory.Link("doc:doctors", "/doctors"); !errors.Is(err, relationtype.ErrInvalidUsage) {
+		t.Errorf("Error is %v, want %v", err, relationtype.ErrInvalidUsage)
+	}
+
+	if _, err := factory.Link("companions", "/companions"); !errors.Is(err, relationtype.ErrUnknownRelation) {
+		t.Errorf("Error is %v, want %v", err, relationtype.ErrUnknownRelation)
+	}
+
+	legacy := factory.ResourceFactory()
+
+	if relation := legacy.CreateResourceLink("doctors", "doc"); relation == nil || relation.FullName() != "doc:doctors" {
+		t.Errorf("CreateResourceLink should create %s: %v", "doc:doctors", relation)
+	}
+
+	if relation := legacy.CreateLink("doctors", "/doctors", "doc"); relation != nil {
+		t.Errorf("Link should be nil: %v", relation)
+	}
+}
+
+func TestResolveHref(t *testing.T) {
+	factory, _ := NewFactory(WithBaseHref("http://example.com/api/v1/"))
+
+	tests := []struct {
+		href string
+		want string
+	}{
+		{"doctors", "http://example.com/api/v1/doctors"},
+		{"/doctors", "http://example.com/doctors"},
+		{"../doctors?page=2", "http://example.com/api/doctors?page=2"},
+		{"doctors{?page}", "http://example.com/api/v1/doctors{?page}"},
+		{"{+path}", "{+path}"},
+		{"https://other.com/doctors", "https://other.com/doctors"},
+	}
+
+	for _, test := range tests {
+		link, err := factory.NewLink(test.href)
+
+		if err != nil || link.Href != test.want {
+			t.Errorf("Href of %s is %v, %v, want %s", test.href, link, err, test.want)
+		}
+	}
+}
+
+func TestFactoryWithHrefResolver(t *testing.T) {
+	resolved := []string{}
+	resolve := func(href string) (string, error) {
+		if href == "invalid" {
+			return "", ErrInvalidURIRef
+		}
+
+		resolved = append(resolved, href)
+		return strings.Replace(href, "http://example.com", "https://proxy.example.com", 1), nil
+	}
+
+	factory, _ := NewFactory(WithBaseHref("http://example.com/api/"), WithHrefResolver(resolve))
+
+	root, _ := factory.RootResource("doctors")
+	link, _ := factory.NewLink("doctors/1")
+
+	if len(resolved) != 2 || resolved[0] != "http://example.com/api/doctors" {
+		t.Errorf("Resolved hrefs are %v, want base href resolved first", resolved)
+	}
+
+	if self := root.Links().Content[relationtype.Self].(*LinkObject); self.Href != "https://proxy.example.com/api/doctors" {
+		t.Errorf("Self href is %s, want %s", self.Href, "https://proxy.example.com/api/doctors")
+	}
+
+	if link.Href != "https://proxy.example.com/api/doctors/1" {
+		t.Errorf("Href is %s, want %s", link.Href, "https://proxy.example.com/api/doctors/1")
+	}
+
+	factory, _ = NewFactory(WithHrefResolver(resolve))
+
+	if _, err := factory.Link(relationtype.Next, "invalid"); !errors.Is(err, ErrInvalidURIRef) {
+		t.Errorf("Error is %v, want %v", err, ErrInvalidURIRef)
+	}
+}
+
+func TestFactoryTemplatedHref(t *testing.T) {
+	factory, _ := NewFactory()
+
+	tests := []struct {
+		href      string
+		templated bool
+	}{
+		{"/orders/{id}", true},
+		{"/orders{?page,size}", true},
+		{"/orders/1", false},
+		{"/orders/{", false},
+	}
+
+	for _, test := range tests {
+		link, err := factory.NewLink(test.href)
+
+		if err != nil {
+			t.Fatalf("NewLink returned error: %v", err)
+		}
+
+		if link.Templated != test.templated {
+			t.Errorf("Templated of %s is %t, want %t", test.href, link.Templated, test.templated)
+		}
+	}
+
+	legacy := NewResourceFactory(nil).CreateLink("item", "/orders/{id}", "")
+
+	if !legacy.Links()[0].Templated {
+		t.Errorf("Link created by ResourceFactory should be templated")
+	}
+}
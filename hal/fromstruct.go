@@ -0,0 +1,262 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagName is the name of the struct tag describing links and embedded resources.
+//
+// Supported tag values are:
+//
+// `hal:"link,rel=author"` for fields of type *LinkObject or []*LinkObject.
+//
+// `hal:"embedded,rel=items"` for struct, struct pointer, Resource or slice of them.
+//
+// The relation name defaults to the field name, if no rel option is provided.
+const TagName string = "hal"
+
+const (
+	linkTagKind     = "link"
+	embeddedTagKind = "embedded"
+)
+
+var (
+	linkObjectType      = reflect.TypeOf((*LinkObject)(nil))
+	linkObjectSliceType = reflect.TypeOf([]*LinkObject{})
+	resourceType        = reflect.TypeOf((*Resource)(nil)).Elem()
+)
+
+// FromStruct creates a Resource from a struct annotated with hal tags.
+// Fields tagged as link become link relations, fields tagged as embedded become
+// embedded resources created by FromStruct as well. All other fields are assigned as
+// data the same way AddDataE does.
+// An embedded struct pointer referencing an enclosing struct, e.g. a parent, results in an error.
+func FromStruct(data interface{}) (Resource, error) {
+	return fromStruct(data, map[structPointer]bool{})
+}
+
+// structPointer identifies a struct by address and type, a struct and its first field share their address.
+type structPointer struct {
+	address uintptr
+	vType   reflect.Type
+}
+
+// fromStruct creates a Resource the way FromStruct does. ancestors are the struct pointers of the
+// enclosing structs.
+func fromStruct(data interface{}, ancestors map[structPointer]bool) (Resource, error) {
+	v, err := structValue(reflect.ValueOf(data))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ptr := reflect.ValueOf(data); ptr.Kind() == reflect.Ptr {
+		key := structPointer{address: ptr.Pointer(), vType: ptr.Type()}
+
+		if ancestors[key] {
+			return nil, errors.New("embedded struct references an enclosing struct")
+		}
+
+		ancestors[key] = true
+		defer delete(ancestors, key)
+	}
+
+	resource := NewResourceObject()
+
+	if err := resource.(DataAdderE).AddDataE(data); err != nil {
+		return nil, err
+	}
+
+	if err := addTaggedFields(resource, v, ancestors); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func structValue(v reflect.Value) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, errors.New("FromStruct requires a non-nil value")
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return v, fmt.Errorf("FromStruct requires a struct value, got %s", v.Kind())
+	}
+
+	return v, nil
+}
+
+func addTaggedFields(resource Resource, v reflect.Value, ancestors map[structPointer]bool) error {
+	vType := v.Type()
+
+	for i := 0; i < vType.NumField(); i++ {
+		tField := vType.Field(i)
+		vField := v.Field(i)
+		tag, ok := tField.Tag.Lookup(TagName)
+
+		if !ok {
+			if !tField.Anonymous {
+				continue
+			}
+
+			// tagged fields of embedded structs are promoted
+			if vField.Kind() == reflect.Ptr {
+				if vField.IsNil() {
+					continue
+				}
+
+				vField = vField.Elem()
+			}
+
+			if vField.Kind() == reflect.Struct {
+				if err := addTaggedFields(resource, vField, ancestors); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		kind, relationName, err := readHALTag(tag, tField.Name)
+
+		if err != nil {
+			return fmt.Errorf("field %s: %w", tField.Name, err)
+		}
+
+		if !vField.CanInterface() {
+			return fmt.Errorf("field %s: tagged field must be exported", tField.Name)
+		}
+
+		switch kind {
+		case linkTagKind:
+			err = addLinkField(resource, relationName, vField)
+		case embeddedTagKind:
+			err = addEmbeddedField(resource, relationName, vField, ancestors)
+		}
+
+		if err != nil {
+			return fmt.Errorf("field %s: %w", tField.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// readHALTag returns kind and relation name of a hal tag value.
+func readHALTag(tag string, fieldName string) (string, string, error) {
+	tokens := strings.Split(tag, ",")
+	kind := strings.TrimSpace(tokens[0])
+	relationName := fieldName
+
+	if kind != linkTagKind && kind != embeddedTagKind {
+		return "", "", fmt.Errorf("unknown hal tag kind %q", kind)
+	}
+
+	for _, token := range tokens[1:] {
+		option := strings.TrimSpace(token)
+
+		if !strings.HasPrefix(option, "rel=") {
+			return "", "", fmt.Errorf("unknown hal tag option %q", option)
+		}
+
+		relationName = strings.TrimPrefix(option, "rel=")
+	}
+
+	return kind, relationName, nil
+}
+
+func addLinkField(resource Resource, relationName string, vField reflect.Value) error {
+	if vField.Type() != linkObjectType && vField.Type() != linkObjectSliceType {
+		return fmt.Errorf("link requires type %s or %s, got %s", linkObjectType, linkObjectSliceType, vField.Type())
+	}
+
+	if vField.IsNil() {
+		return nil
+	}
+
+	relation, err := NewLinkRelation(relationName)
+
+	if err != nil {
+		return err
+	}
+
+	switch links := vField.Interface().(type) {
+	case *LinkObject:
+		relation.SetLink(links)
+	case []*LinkObject:
+		for _, link := range links {
+			if link == nil {
+				return errors.New("link must not be nil")
+			}
+		}
+
+		relation.SetLinks(links)
+	}
+
+	resource.AddLink(relation)
+
+	return nil
+}
+
+func addEmbeddedField(resource Resource, relationName string, vField reflect.Value, ancestors map[structPointer]bool) error {
+	if (vField.Kind() == reflect.Ptr || vField.Kind() == reflect.Slice || vField.Kind() == reflect.Interface) && vField.IsNil() {
+		return nil
+	}
+
+	relation, err := NewResourceRelation(relationName)
+
+	if err != nil {
+		return err
+	}
+
+	if vField.Kind() == reflect.Slice {
+		resources := []Resource{}
+
+		for i := 0; i < vField.Len(); i++ {
+			embedded, err := toEmbeddedResource(vField.Index(i), ancestors)
+
+			if err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+
+			resources = append(resources, embedded)
+		}
+
+		relation.SetResources(resources)
+	} else {
+		embedded, err := toEmbeddedResource(vField, ancestors)
+
+		if err != nil {
+			return err
+		}
+
+		relation.SetResource(embedded)
+	}
+
+	resource.AddResource(relation)
+
+	return nil
+}
+
+func toEmbeddedResource(v reflect.Value, ancestors map[structPointer]bool) (Resource, error) {
+	if v.Type().Implements(resourceType) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil, errors.New("embedded resource must not be nil")
+		}
+
+		return v.Interface().(Resource), nil
+	}
+
+	return fromStruct(v.Interface(), ancestors)
+}
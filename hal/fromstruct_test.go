@@ -0,0 +1,187 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+type taggedCompanion struct {
+	Self *LinkObject `hal:"link,rel=self"`
+	Name string      `json:"name"`
+}
+
+type taggedMeta struct {
+	Help *LinkObject `hal:"link,rel=help"`
+}
+
+type taggedDoctor struct {
+	taggedMeta
+	Self       *LinkObject       `hal:"link,rel=self"`
+	Actors     []*LinkObject     `hal:"link,rel=actors"`
+	Missing    *LinkObject       `hal:"link"`
+	Companions []taggedCompanion `hal:"embedded,rel=companions"`
+	Enemy      *taggedCompanion  `hal:"embedded,rel=enemy"`
+	Tardis     Resource          `hal:"embedded"`
+	Name       string            `json:"name"`
+	Number     int               `json:"number"`
+}
+
+func TestFromStruct(t *testing.T) {
+	self, _ := NewLinkObject("/docwhoapi/doctors/1")
+	actor, _ := NewLinkObject("/docwhoapi/actors/1")
+	companionSelf, _ := NewLinkObject("/docwhoapi/companions/1")
+	help, _ := NewLinkObject("/docwhoapi/help")
+	tardis := NewResourceObject()
+	tardis.Data()["type"] = "Type 40"
+
+	doctor := &taggedDoctor{
+		taggedMeta: taggedMeta{Help: help},
+		Self:       self,
+		Actors:     []*LinkObject{actor},
+		Companions: []taggedCompanion{{Self: companionSelf, Name: "Susan Foreman"}},
+		Tardis:     tardis,
+		Name:       "The Doctor",
+		Number:     1,
+	}
+
+	resource, err := FromStruct(doctor)
+
+	if err != nil {
+		t.Fatalf("FromStruct returns error: %s", err)
+	}
+
+	data := resource.Data()
+
+	if count := len(data); count != 2 {
+		t.Errorf("Data amount %d, want %d", count, 2)
+	}
+
+	if name := data["name"]; name != doctor.Name {
+		t.Errorf("Data name is %v, want %s", name, doctor.Name)
+	}
+
+	links := resource.Links().Content
+
+	if count := len(links); count != 3 {
+		t.Errorf("Link relations count %d, want %d", count, 3)
+	}
+
+	if link := links[relationtype.Self]; link != self {
+		t.Errorf("Self link is %v, want %v", link, self)
+	}
+
+	if link := links["help"]; link != help {
+		t.Errorf("Help link is %v, want %v", link, help)
+	}
+
+	if actors, ok := links["actors"].([]*LinkObject); !ok || len(actors) != 1 {
+		t.Errorf("Actors link is %v, want %v", links["actors"], []*LinkObject{actor})
+	}
+
+	if _, ok := links["Missing"]; ok {
+		t.Errorf("Nil link should not be added")
+	}
+
+	embedded := resourceRelationsOf(resource)
+
+	if count := len(embedded); count != 2 {
+		t.Fatalf("Resource relations count %d, want %d", count, 2)
+	}
+
+	if name := embedded[0].FullName(); name != "companions" || !embedded[0].IsResourceSet() {
+		t.Errorf("Resource relation %s should be a resource set", name)
+	}
+
+	companion := embedded[0].Resources()[0]
+
+	if name := companion.Data()["name"]; name != "Susan Foreman" {
+		t.Errorf("Embedded name is %v, want %s", name, "Susan Foreman")
+	}
+
+	if link := companion.Links().Content[relationtype.Self]; link != companionSelf {
+		t.Errorf("Embedded self link is %v, want %v", link, companionSelf)
+	}
+
+	if name := embedded[1].FullName(); name != "Tardis" || embedded[1].Resources()[0] != tardis {
+		t.Errorf("Resource relation %s does not contain assigned resource", name)
+	}
+}
+
+func TestFromStructWithInvalidValues(t *testing.T) {
+	type invalidKind struct {
+		A *LinkObject `hal:"form"`
+	}
+
+	type invalidOption struct {
+		A *LinkObject `hal:"link,name=a"`
+	}
+
+	type invalidLinkType struct {
+		A string `hal:"link,rel=a"`
+	}
+
+	type invalidEmbeddedType struct {
+		A []int `hal:"embedded,rel=a"`
+	}
+
+	type unexportedField struct {
+		a *LinkObject `hal:"link,rel=a"`
+	}
+
+	link, _ := NewLinkObject("/a")
+	values := []interface{}{
+		nil,
+		"test",
+		(*taggedDoctor)(nil),
+		invalidKind{},
+		invalidOption{},
+		invalidLinkType{A: "/a"},
+		invalidEmbeddedType{A: []int{1}},
+		unexportedField{a: link},
+		taggedDoctor{Actors: []*LinkObject{nil}},
+	}
+
+	for _, value := range values {
+		if _, err := FromStruct(value); err == nil {
+			t.Errorf("FromStruct should return an error for %#v", value)
+		}
+	}
+}
+
+type taggedNode struct {
+	Name     string        `json:"name"`
+	Parent   *taggedNode   `hal:"embedded,rel=parent"`
+	Children []*taggedNode `hal:"embedded,rel=children"`
+}
+
+func TestFromStructWithCycle(t *testing.T) {
+	root := &taggedNode{Name: "root"}
+	child := &taggedNode{Name: "child", Parent: root}
+	root.Children = []*taggedNode{child}
+
+	if _, err := FromStruct(root); err == nil {
+		t.Errorf("FromStruct should return an error for a struct referencing itself")
+	}
+
+	if _, err := FromStruct(*child); err == nil {
+		t.Errorf("FromStruct should return an error for a struct value referencing itself")
+	}
+
+	shared := &taggedNode{Name: "shared"}
+	siblings := &taggedNode{Name: "root", Children: []*taggedNode{{Name: "first", Parent: shared}, {Name: "second", Parent: shared}}}
+
+	resource, err := FromStruct(siblings)
+
+	if err != nil {
+		t.Fatalf("FromStruct returns error: %s", err)
+	}
+
+	if children := resourceRelationsOf(resource)[0].Resources(); len(children) != 2 {
+		t.Errorf("Children count == %d, want %d", len(children), 2)
+	}
+}
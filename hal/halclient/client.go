@@ -0,0 +1,116 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pmoule/go2hal/hal"
+)
+
+// acceptHeader is sent with each request.
+const acceptHeader = "application/hal+json, application/json;q=0.9"
+
+// ResponseError is returned for responses with a status code other than 2xx.
+type ResponseError struct {
+	URL        string
+	StatusCode int
+}
+
+// Error returns a description of the unexpected response.
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("GET %s: unexpected status %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient makes a Client send requests using provided http.Client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
+// Client traverses a HAL API starting at an entry URL.
+type Client struct {
+	entry      *url.URL
+	httpClient *http.Client
+	decoder    hal.Decoder
+}
+
+// NewClient creates a Client starting at provided entry URL, which must be absolute.
+func NewClient(entry string, options ...Option) (*Client, error) {
+	entryURL, err := url.Parse(entry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !entryURL.IsAbs() {
+		return nil, errors.New("entry URL must be absolute")
+	}
+
+	client := &Client{entry: entryURL, httpClient: http.DefaultClient, decoder: hal.NewDecoder()}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client, nil
+}
+
+// Get requests the entry resource.
+func (c *Client) Get(ctx context.Context) (hal.Resource, error) {
+	return c.Follow().Get(ctx)
+}
+
+// Follow starts a Traversal following provided link relations step by step.
+// The Traversal keeps a copy of rels.
+func (c *Client) Follow(rels ...string) *Traversal {
+	return &Traversal{client: c, rels: append([]string{}, rels...), values: map[string]interface{}{}}
+}
+
+// fetch requests a HAL document and decodes it. The URL of the response, the final one of a
+// redirected request, is returned as base for relative hrefs.
+func (c *Client) fetch(ctx context.Context, target *url.URL) (hal.Resource, *url.URL, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", acceptHeader)
+	response, err := c.httpClient.Do(request)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, nil, &ResponseError{URL: target.String(), StatusCode: response.StatusCode}
+	}
+
+	body, err := io.ReadAll(response.Body)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resource, err := c.decoder.FromJSON(body)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("GET %s: %w", target, err)
+	}
+
+	return resource, response.Request.URL, nil
+}
@@ -0,0 +1,7 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+// Package halclient provides a hypermedia client traversing HAL APIs
+// by following link relations.
+package halclient
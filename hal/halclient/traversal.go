@@ -0,0 +1,144 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/pmoule/go2hal/hal"
+)
+
+// Traversal follows link relations starting at the entry URL of a Client.
+//
+// A relation is matched by its full name, e.g. "doc:orders", or by its CURIE expanded URI,
+// e.g. "http://example.com/docs/rels/orders". If an embedded resource is present for a relation,
+// it is used instead of requesting the linked resource.
+type Traversal struct {
+	client *Client
+	rels   []string
+	values map[string]interface{}
+}
+
+// Follow appends further link relations to follow.
+func (t *Traversal) Follow(rels ...string) *Traversal {
+	t.rels = append(t.rels, rels...)
+
+	return t
+}
+
+// WithTemplate provides values for expanding templated links.
+func (t *Traversal) WithTemplate(values map[string]interface{}) *Traversal {
+	for name, value := range values {
+		t.values[name] = value
+	}
+
+	return t
+}
+
+// Get follows all link relations and returns the resource of the last one.
+func (t *Traversal) Get(ctx context.Context) (hal.Resource, error) {
+	resource, base, err := t.client.fetch(ctx, t.client.entry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rel := range t.rels {
+		if embedded, ok := findEmbeddedResource(resource, rel); ok {
+			resource = embedded
+			continue
+		}
+
+		link, ok := findLink(resource, rel)
+
+		if !ok {
+			return nil, fmt.Errorf("relation %s not found", rel)
+		}
+
+		target, err := t.resolve(base, link)
+
+		if err != nil {
+			return nil, fmt.Errorf("relation %s: %w", rel, err)
+		}
+
+		resource, base, err = t.client.fetch(ctx, target)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resource, nil
+}
+
+// resolve expands a templated link and resolves its href against base.
+func (t *Traversal) resolve(base *url.URL, link *hal.LinkObject) (*url.URL, error) {
+	href, err := link.Expand(t.values)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := url.Parse(href)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ResolveReference(reference), nil
+}
+
+// findLink returns the first Link Object of the relation matching rel.
+func findLink(resource hal.Resource, rel string) (*hal.LinkObject, bool) {
+	lister, ok := resource.(hal.RelationLister)
+
+	if !ok {
+		return nil, false
+	}
+
+	for _, relation := range lister.LinkRelations() {
+		if links := relation.Links(); matchesRelation(relation, rel) && len(links) > 0 {
+			return links[0], true
+		}
+	}
+
+	return nil, false
+}
+
+// findEmbeddedResource returns the first embedded resource of the relation matching rel.
+func findEmbeddedResource(resource hal.Resource, rel string) (hal.Resource, bool) {
+	lister, ok := resource.(hal.RelationLister)
+
+	if !ok {
+		return nil, false
+	}
+
+	for _, relation := range lister.ResourceRelations() {
+		if resources := relation.Resources(); matchesRelation(relation, rel) && len(resources) > 0 {
+			return resources[0], true
+		}
+	}
+
+	return nil, false
+}
+
+// matchesRelation checks whether rel is the full name or the CURIE expanded URI of relation.
+func matchesRelation(relation hal.Relation, rel string) bool {
+	if relation.FullName() == rel {
+		return true
+	}
+
+	// relations without CURIE have a full name equal to their name
+	if relation.FullName() == relation.Name() {
+		return false
+	}
+
+	curieLink := relation.CurieLink()
+	expanded, err := curieLink.Expand(map[string]interface{}{"rel": relation.Name()})
+
+	return err == nil && expanded == rel
+}
@@ -0,0 +1,205 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var testDocuments = map[string]string{
+	"/api": `{
+		"_links": {
+			"self": {"href": "/api"},
+			"curies": [{"name": "doc", "href": "http://example.com/docs/rels/{rel}", "templated": true}],
+			"doc:orders": {"href": "api/orders"},
+			"doc:find": {"href": "/api/orders{/id}", "templated": true}
+		}
+	}`,
+	"/api/orders": `{
+		"_links": {
+			"self": {"href": "/api/orders"},
+			"next": {"href": "?page=2"}
+		},
+		"_embedded": {
+			"first": {"_links": {"self": {"href": "/api/orders/1"}}, "id": 1}
+		}
+	}`,
+	"/api/orders?page=2": `{"_links": {"self": {"href": "/api/orders?page=2"}}, "page": 2}`,
+	"/api/orders/7":      `{"_links": {"self": {"href": "/api/orders/7"}}, "id": 7}`,
+}
+
+var testRedirects = map[string]string{
+	"/old/orders": "/api/orders",
+}
+
+func newTestServer(requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RequestURI())
+
+		if location, ok := testRedirects[r.URL.RequestURI()]; ok {
+			http.Redirect(w, r, location, http.StatusFound)
+			return
+		}
+
+		document, ok := testDocuments[r.URL.RequestURI()]
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/hal+json")
+		w.Write([]byte(document))
+	}))
+}
+
+func TestFollow(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	client, err := NewClient(server.URL + "/api")
+
+	if err != nil {
+		t.Fatalf("NewClient returns error: %s", err)
+	}
+
+	resource, err := client.Follow("doc:orders", "next").Get(context.Background())
+
+	if err != nil {
+		t.Fatalf("Get returns error: %s", err)
+	}
+
+	if page := resource.Data()["page"]; page != json.Number("2") {
+		t.Errorf("Page is %v, want %d", page, 2)
+	}
+
+	wanted := []string{"/api", "/api/orders", "/api/orders?page=2"}
+
+	if len(requests) != len(wanted) {
+		t.Fatalf("Requests are %v, want %v", requests, wanted)
+	}
+
+	for i, request := range requests {
+		if request != wanted[i] {
+			t.Errorf("Request %d is %s, want %s", i, request, wanted[i])
+		}
+	}
+}
+
+func TestFollowWithExpandedCurie(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL + "/api")
+	resource, err := client.Follow("http://example.com/docs/rels/find").
+		WithTemplate(map[string]interface{}{"id": 7}).
+		Get(context.Background())
+
+	if err != nil {
+		t.Fatalf("Get returns error: %s", err)
+	}
+
+	if id := resource.Data()["id"]; id != json.Number("7") {
+		t.Errorf("Id is %v, want %d", id, 7)
+	}
+}
+
+func TestFollowEmbeddedResource(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL + "/api")
+	resource, err := client.Follow("doc:orders").Follow("first").Get(context.Background())
+
+	if err != nil {
+		t.Fatalf("Get returns error: %s", err)
+	}
+
+	if id := resource.Data()["id"]; id != json.Number("1") {
+		t.Errorf("Id is %v, want %d", id, 1)
+	}
+
+	if count := len(requests); count != 2 {
+		t.Errorf("Request count %d, want %d", count, 2)
+	}
+}
+
+func TestFollowAfterRedirect(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL + "/old/orders")
+	resource, err := client.Follow("next").Get(context.Background())
+
+	if err != nil {
+		t.Fatalf("Get returns error: %s", err)
+	}
+
+	if page := resource.Data()["page"]; page != json.Number("2") {
+		t.Errorf("Page is %v, want %d", page, 2)
+	}
+
+	wanted := []string{"/old/orders", "/api/orders", "/api/orders?page=2"}
+
+	if len(requests) != len(wanted) {
+		t.Fatalf("Requests are %v, want %v", requests, wanted)
+	}
+
+	for i, request := range requests {
+		if request != wanted[i] {
+			t.Errorf("Request %d is %s, want %s", i, request, wanted[i])
+		}
+	}
+}
+
+func TestFollowKeepsCallerSlice(t *testing.T) {
+	rels := make([]string, 1, 2)
+	rels[0] = "doc:orders"
+	others := append(rels, "last")
+
+	client, _ := NewClient("http://example.com/api")
+	client.Follow(rels...).Follow("first")
+
+	if rel := others[1]; rel != "last" {
+		t.Errorf("Caller rel is %s, want %s", rel, "last")
+	}
+}
+
+func TestFollowWithErrors(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL + "/api")
+
+	if _, err := client.Follow("unknown").Get(context.Background()); err == nil {
+		t.Errorf("Get should return an error for unknown relation")
+	}
+
+	client, _ = NewClient(server.URL + "/missing")
+	_, err := client.Get(context.Background())
+	var responseError *ResponseError
+
+	if !errors.As(err, &responseError) {
+		t.Fatalf("Get error is %T, want %T", err, responseError)
+	}
+
+	if responseError.StatusCode != http.StatusNotFound {
+		t.Errorf("Status code is %d, want %d", responseError.StatusCode, http.StatusNotFound)
+	}
+
+	if _, err := NewClient("/api"); err == nil {
+		t.Errorf("NewClient should return an error for relative entry URL")
+	}
+}
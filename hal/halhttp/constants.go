@@ -0,0 +1,11 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+// MediaTypeIdentifier is the media type of HAL documents.
+const MediaTypeIdentifier = "application/hal+json"
+
+// JSONMediaTypeIdentifier is the generic JSON media type, HAL documents are served as well.
+const JSONMediaTypeIdentifier = "application/json"
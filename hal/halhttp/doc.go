@@ -0,0 +1,7 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+// Package halhttp provides net/http integration for writing HAL documents
+// as content-negotiated HTTP responses and building links of requests behind reverse proxies.
+package halhttp
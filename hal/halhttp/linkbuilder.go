@@ -0,0 +1,297 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal"
+)
+
+// LinkBuilderOption configures a Builder created by LinkBuilder.
+type LinkBuilderOption func(*linkBuilderOptions)
+
+type linkBuilderOptions struct {
+	trustedProxies []netip.Prefix
+}
+
+// WithTrustedProxies makes LinkBuilder honor the Forwarded and X-Forwarded-* headers of requests
+// sent by proxies with an address in one of provided prefixes. Without trusted proxies, all
+// forwarded headers are ignored, so clients can't spoof scheme, host and path prefix.
+func WithTrustedProxies(prefixes ...netip.Prefix) LinkBuilderOption {
+	return func(options *linkBuilderOptions) {
+		options.trustedProxies = append(options.trustedProxies, prefixes...)
+	}
+}
+
+// Builder builds absolute links with the scheme, host and path prefix a client used to send
+// a request, even if the request passed reverse proxies.
+type Builder struct {
+	origin *url.URL
+	prefix string
+	base   *url.URL
+}
+
+// LinkBuilder returns a Builder for r. If r was sent by a trusted proxy, scheme, host and path
+// prefix are taken from the Forwarded header (RFC 7239), or the X-Forwarded-Proto,
+// X-Forwarded-Host and X-Forwarded-Prefix headers. Forwarded takes precedence. If several
+// trusted proxies were passed, the values set by the proxy the client connected to are used.
+func LinkBuilder(r *http.Request, options ...LinkBuilderOption) *Builder {
+	builderOptions := linkBuilderOptions{}
+
+	for _, option := range options {
+		option(&builderOptions)
+	}
+
+	scheme := "http"
+
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	forwarded := forwardedValues{proto: scheme, host: r.Host}
+
+	if builderOptions.isTrusted(r.RemoteAddr) {
+		forwarded = builderOptions.forwarded(r.Header, forwarded)
+	}
+
+	origin := &url.URL{Scheme: forwarded.proto, Host: forwarded.host}
+	base := &url.URL{Scheme: origin.Scheme, Host: origin.Host, Path: forwarded.prefix + r.URL.Path, RawQuery: r.URL.RawQuery}
+
+	if r.URL.RawPath != "" {
+		base.RawPath = forwarded.prefix + r.URL.RawPath
+	}
+
+	return &Builder{origin: origin, prefix: forwarded.prefix, base: base}
+}
+
+// URL returns the URL of the request as sent by the client.
+func (b *Builder) URL() *url.URL {
+	result := *b.base
+
+	return &result
+}
+
+// Resolve returns the absolute href for provided href. Path-absolute hrefs like /doctors get the
+// path prefix, relative hrefs are resolved against the request URL and absolute hrefs are returned
+// unchanged. URI Template expressions are kept unchanged.
+func (b *Builder) Resolve(href string) (string, error) {
+	if strings.HasPrefix(href, "/") && !strings.HasPrefix(href, "//") {
+		return hal.ResolveHref(b.origin, b.prefix+href)
+	}
+
+	return hal.ResolveHref(b.base, href)
+}
+
+// Link creates a LinkObject with the resolved href configured by provided options.
+func (b *Builder) Link(href string, options ...hal.LinkOption) (*hal.LinkObject, error) {
+	resolved, err := b.Resolve(href)
+
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := hal.NewLinkObject(resolved)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range options {
+		if err := option(link); err != nil {
+			return nil, err
+		}
+	}
+
+	return link, nil
+}
+
+// FactoryOption returns a hal.FactoryOption making a hal.Factory resolve all hrefs by the Builder.
+// The legacy hal.ResourceFactory is available by hal.Factory.ResourceFactory.
+func (b *Builder) FactoryOption() hal.FactoryOption {
+	return hal.WithHrefResolver(b.Resolve)
+}
+
+type forwardedValues struct {
+	proto  string
+	host   string
+	prefix string
+}
+
+// forwarded returns the values of the forwarded headers, if valid. X-Forwarded-Prefix is used
+// together with Forwarded as well.
+func (o linkBuilderOptions) forwarded(header http.Header, values forwardedValues) forwardedValues {
+	proto, host := "", ""
+	hops := headerList(header, "X-Forwarded-For")
+	count := len(hops)
+	index := o.firstTrusted(count, func(i int) string { return hops[i] })
+
+	if elements := parseForwarded(header.Values("Forwarded")); len(elements) > 0 {
+		count = len(elements)
+		index = o.firstTrusted(count, func(i int) string { return elements[i]["for"] })
+		proto, host = elements[index]["proto"], elements[index]["host"]
+	} else {
+		proto = hopValue(headerList(header, "X-Forwarded-Proto"), count, index)
+		host = hopValue(headerList(header, "X-Forwarded-Host"), count, index)
+	}
+
+	// Forwarded has no parameter for the path prefix
+	prefix := hopValue(headerList(header, "X-Forwarded-Prefix"), count, index)
+
+	if proto = strings.ToLower(proto); proto == "http" || proto == "https" {
+		values.proto = proto
+	}
+
+	if isHost(host) {
+		values.host = host
+	}
+
+	if prefix = strings.TrimRight(prefix, "/"); strings.HasPrefix(prefix, "/") && !strings.HasPrefix(prefix, "//") {
+		if _, err := url.ParseRequestURI(prefix); err == nil && !strings.ContainsAny(prefix, "?#") {
+			values.prefix = prefix
+		}
+	}
+
+	return values
+}
+
+// firstTrusted returns the index of the first hop set by a trusted proxy. Hops are walked from
+// right to left, as long as the hop's client is a trusted proxy as well.
+func (o linkBuilderOptions) firstTrusted(count int, client func(i int) string) int {
+	index := count - 1
+
+	for index > 0 && o.isTrusted(client(index)) {
+		index--
+	}
+
+	return max(index, 0)
+}
+
+// hopValue returns the value of the hop at index. Lists not matching the number of hops are
+// set by the last proxy, their last value is returned.
+func hopValue(values []string, hops int, index int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	if len(values) == hops {
+		return values[index]
+	}
+
+	return values[len(values)-1]
+}
+
+// isTrusted returns true for addresses of trusted proxies. Ports, brackets and quotes are ignored.
+func (o linkBuilderOptions) isTrusted(address string) bool {
+	address = strings.Trim(strings.TrimSpace(address), `"`)
+
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	ip, err := netip.ParseAddr(strings.Trim(address, "[]"))
+
+	if err != nil {
+		return false
+	}
+
+	ip = ip.Unmap()
+
+	for _, prefix := range o.trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isHost returns true for a host with optional port, without userinfo, path, query or fragment.
+func isHost(host string) bool {
+	if host == "" {
+		return false
+	}
+
+	parsed, err := url.Parse("http://" + host)
+
+	return err == nil && parsed.Host == host && parsed.User == nil && parsed.Path == "" && parsed.RawQuery == "" &&
+		!parsed.ForceQuery && parsed.Fragment == ""
+}
+
+// headerList returns the comma separated values of all header fields with provided name.
+func headerList(header http.Header, name string) []string {
+	values := []string{}
+
+	for _, field := range header.Values(name) {
+		for _, value := range strings.Split(field, ",") {
+			values = append(values, strings.TrimSpace(value))
+		}
+	}
+
+	return values
+}
+
+// parseForwarded parses the elements of Forwarded header fields. Parameter names are lower case,
+// quoted values are unquoted. A malformed header makes all elements being ignored.
+func parseForwarded(fields []string) []map[string]string {
+	elements := []map[string]string{}
+
+	for _, field := range fields {
+		for _, part := range splitQuoted(field, ',') {
+			element := map[string]string{}
+
+			for _, pair := range splitQuoted(part, ';') {
+				if strings.TrimSpace(pair) == "" {
+					continue
+				}
+
+				name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+
+				if !ok || name == "" {
+					return nil
+				}
+
+				if strings.HasPrefix(value, `"`) {
+					if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+						return nil
+					}
+
+					value = strings.ReplaceAll(value[1:len(value)-1], `\`, "")
+				}
+
+				element[strings.ToLower(name)] = value
+			}
+
+			elements = append(elements, element)
+		}
+	}
+
+	return elements
+}
+
+// splitQuoted splits s at each separator outside of quoted strings.
+func splitQuoted(s string, separator byte) []string {
+	parts := []string{}
+	quoted := false
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quoted:
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case s[i] == separator && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
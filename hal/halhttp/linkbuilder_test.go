@@ -0,0 +1,141 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal"
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+var trustedProxies = WithTrustedProxies(netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8"))
+
+func TestLinkBuilder(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     map[string]string
+		want       string
+	}{
+		{"no proxy", "192.0.2.1:1234", nil, "http://example.com/doctors/1"},
+		{"untrusted proxy", "192.0.2.1:1234",
+			map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.com"},
+			"http://example.com/doctors/1"},
+		{"x-forwarded", "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "api.example.org", "X-Forwarded-Prefix": "/api/"},
+			"https://api.example.org/api/doctors/1"},
+		{"forwarded", "10.0.0.1:1234",
+			map[string]string{"Forwarded": `for=192.0.2.60;proto=https;host="api.example.org:8443"`, "X-Forwarded-Host": "other.org"},
+			"https://api.example.org:8443/doctors/1"},
+		{"forwarded ipv6 proxy", "[fd00::1]:1234",
+			map[string]string{"Forwarded": `For="[2001:db8:cafe::17]:4711";Proto=https;Host=api.example.org`},
+			"https://api.example.org/doctors/1"},
+		{"forwarded chain", "10.0.0.1:1234",
+			map[string]string{"Forwarded": "for=192.0.2.1;host=evil.com, for=192.0.2.60;host=api.example.org, for=10.0.0.2;host=internal"},
+			"http://api.example.org/doctors/1"},
+		{"x-forwarded chain", "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "192.0.2.1, 192.0.2.60, 10.0.0.2", "X-Forwarded-Host": "evil.com, api.example.org, internal"},
+			"http://api.example.org/doctors/1"},
+		{"invalid values", "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-Proto": "ftp", "X-Forwarded-Host": "evil.com/path", "X-Forwarded-Prefix": "//evil.com"},
+			"http://example.com/doctors/1"},
+		{"malformed forwarded", "10.0.0.1:1234",
+			map[string]string{"Forwarded": `host="api.example.org`, "X-Forwarded-Host": "other.org"},
+			"http://other.org/doctors/1"},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/doctors?page=1", nil)
+		request.RemoteAddr = test.remoteAddr
+
+		for name, value := range test.header {
+			request.Header.Set(name, value)
+		}
+
+		href, err := LinkBuilder(request, trustedProxies).Resolve("/doctors/1")
+
+		if err != nil || href != test.want {
+			t.Errorf("%s: href is %s, %v, want %s", test.name, href, err, test.want)
+		}
+	}
+}
+
+func TestLinkBuilderResolve(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "https://example.com/doctors/?page=1", nil)
+	request.TLS = &tls.ConnectionState{}
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-Prefix", "/api")
+	builder := LinkBuilder(request, trustedProxies)
+
+	if value := builder.URL().String(); value != "https://example.com/api/doctors/?page=1" {
+		t.Errorf("URL is %s, want %s", value, "https://example.com/api/doctors/?page=1")
+	}
+
+	tests := []struct {
+		href string
+		want string
+	}{
+		{"/doctors/1", "https://example.com/api/doctors/1"},
+		{"1", "https://example.com/api/doctors/1"},
+		{"../companions{?page}", "https://example.com/api/companions{?page}"},
+		{"/doctors/{id}", "https://example.com/api/doctors/{id}"},
+		{"//other.org/doctors", "https://other.org/doctors"},
+		{"http://other.org/doctors", "http://other.org/doctors"},
+	}
+
+	for _, test := range tests {
+		href, err := builder.Resolve(test.href)
+
+		if err != nil || href != test.want {
+			t.Errorf("Href of %s is %s, %v, want %s", test.href, href, err, test.want)
+		}
+	}
+
+	link, err := builder.Link("/doctors{?name}", hal.Templated(), hal.Titled("Search"))
+
+	if err != nil {
+		t.Fatalf("Link returned error: %v", err)
+	}
+
+	if link.Href != "https://example.com/api/doctors{?name}" || !link.Templated || link.Title != "Search" {
+		t.Errorf("Link is %v", link)
+	}
+
+	if _, err := builder.Link(""); err == nil {
+		t.Errorf("Link should return error for empty href")
+	}
+}
+
+func TestLinkBuilderFactoryOption(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/doctors", nil)
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-Proto", "https")
+	request.Header.Set("X-Forwarded-Host", "api.example.org")
+
+	factory, err := hal.NewFactory(LinkBuilder(request, trustedProxies).FactoryOption())
+
+	if err != nil {
+		t.Fatalf("NewFactory returned error: %v", err)
+	}
+
+	resourceFactory := factory.ResourceFactory()
+	root := resourceFactory.CreateRootResource("/doctors")
+	self := root.Links().Content[relationtype.Self].(*hal.LinkObject)
+
+	if self.Href != "https://api.example.org/doctors" {
+		t.Errorf("Self href is %s, want %s", self.Href, "https://api.example.org/doctors")
+	}
+
+	next := resourceFactory.CreateLink(relationtype.Next, "/doctors?page=2", "")
+
+	if href := next.Links()[0].Href; href != "https://api.example.org/doctors?page=2" {
+		t.Errorf("Next href is %s, want %s", href, "https://api.example.org/doctors?page=2")
+	}
+}
@@ -0,0 +1,107 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/pmoule/go2hal/halforms"
+)
+
+// supportedMediaTypes lists all media types a HAL document is served as in order of preference.
+var supportedMediaTypes = []string{MediaTypeIdentifier, JSONMediaTypeIdentifier, halforms.MediaTypeIdentifier}
+
+// resourceMediaTypes lists the media types a hal.Resource without HAL-FORMS templates is served as.
+var resourceMediaTypes = []string{MediaTypeIdentifier, JSONMediaTypeIdentifier}
+
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+// Negotiate returns the supported media type best matching the provided Accept header value.
+// An empty value accepts MediaTypeIdentifier. If no supported media type is acceptable,
+// false is returned.
+func Negotiate(accept string) (string, bool) {
+	return negotiate(accept, supportedMediaTypes)
+}
+
+// negotiate returns the media type of mediaTypes best matching the provided Accept header value.
+func negotiate(accept string, mediaTypes []string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return MediaTypeIdentifier, true
+	}
+
+	ranges := parseAccept(accept)
+	result := ""
+	resultQuality := 0.0
+
+	for _, mediaType := range mediaTypes {
+		quality := acceptedQuality(mediaType, ranges)
+
+		if quality > resultQuality {
+			result = mediaType
+			resultQuality = quality
+		}
+	}
+
+	return result, result != ""
+}
+
+// parseAccept parses all media ranges of an Accept header value. Malformed ranges are ignored.
+func parseAccept(accept string) []mediaRange {
+	ranges := []mediaRange{}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+
+		if value, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(value, 64)
+
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	return ranges
+}
+
+// acceptedQuality returns the quality of the most specific media range matching mediaType.
+func acceptedQuality(mediaType string, ranges []mediaRange) float64 {
+	quality := 0.0
+	specificity := -1
+	mainType := strings.SplitN(mediaType, "/", 2)[0]
+
+	for _, r := range ranges {
+		current := -1
+
+		switch r.mediaType {
+		case mediaType:
+			current = 2
+		case mainType + "/*":
+			current = 1
+		case "*/*":
+			current = 0
+		}
+
+		if current > specificity {
+			specificity = current
+			quality = r.quality
+		}
+	}
+
+	return quality
+}
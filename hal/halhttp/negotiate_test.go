@@ -0,0 +1,57 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"testing"
+
+	"github.com/pmoule/go2hal/halforms"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", MediaTypeIdentifier},
+		{"*/*", MediaTypeIdentifier},
+		{"application/*", MediaTypeIdentifier},
+		{"application/hal+json", MediaTypeIdentifier},
+		{"application/json", JSONMediaTypeIdentifier},
+		{halforms.MediaTypeIdentifier, halforms.MediaTypeIdentifier},
+		{"application/json, application/hal+json;q=0.5", JSONMediaTypeIdentifier},
+		{"application/hal+json;q=0, */*", JSONMediaTypeIdentifier},
+		{"text/html, application/prs.hal-forms+json;q=0.9, */*;q=0.1", halforms.MediaTypeIdentifier},
+		{"text/html;q=1, invalid, application/json;q=0.8", JSONMediaTypeIdentifier},
+	}
+
+	for _, test := range tests {
+		mediaType, ok := Negotiate(test.accept)
+
+		if !ok {
+			t.Errorf("Negotiate(%q) should be acceptable", test.accept)
+			continue
+		}
+
+		if mediaType != test.want {
+			t.Errorf("Negotiate(%q) == %s, want %s", test.accept, mediaType, test.want)
+		}
+	}
+}
+
+func TestNegotiateWithUnacceptableMediaTypes(t *testing.T) {
+	values := []string{
+		"text/html",
+		"application/xml, text/*",
+		"*/*;q=0",
+		"application/json;q=2",
+	}
+
+	for _, value := range values {
+		if mediaType, ok := Negotiate(value); ok {
+			t.Errorf("Negotiate(%q) == %s, should not be acceptable", value, mediaType)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pmoule/go2hal/hal"
+)
+
+// Errors reported by a Router. Use errors.Is to check for them.
+var (
+	ErrUnknownRoute     = errors.New("unknown route")
+	ErrUnknownParameter = errors.New("unknown route parameter")
+)
+
+// Router is an http.ServeMux with named routes. The path of a named route is built from its
+// pattern by URL, so hrefs don't drift out of sync with the registered patterns.
+type Router struct {
+	mux    *http.ServeMux
+	mutex  sync.RWMutex
+	routes map[string][]routeSegment
+}
+
+// routeSegment is a path segment of a pattern. Segments without wildcard are literals.
+type routeSegment struct {
+	literal  string
+	wildcard string
+	multiple bool
+}
+
+// NewRouter creates a Router.
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux(), routes: map[string][]routeSegment{}}
+}
+
+// Handle registers the handler for pattern the same way http.ServeMux does, e.g.
+// GET /orders/{id}/items/{itemID}. A non-empty name makes the route available for URL and Link.
+// Handle panics if the pattern is invalid or conflicts with a registered one, or if the name is
+// registered already.
+func (rt *Router) Handle(name string, pattern string, handler http.Handler) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	if _, ok := rt.routes[name]; ok && name != "" {
+		panic(fmt.Sprintf("halhttp: route %s registered already", name))
+	}
+
+	rt.mux.Handle(pattern, handler)
+
+	if name != "" {
+		rt.routes[name] = parseRoute(pattern)
+	}
+}
+
+// HandleFunc registers the handler function for pattern the same way Handle does.
+func (rt *Router) HandleFunc(name string, pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	rt.Handle(name, pattern, http.HandlerFunc(handler))
+}
+
+// ServeHTTP dispatches the request to the handler whose pattern matches the request.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.mux.ServeHTTP(w, r)
+}
+
+// URL returns the path of a named route with wildcards replaced by params. For an unknown route
+// or an unknown parameter an empty string is returned, use URLE to get notified.
+func (rt *Router) URL(name string, params map[string]string) string {
+	path, _ := rt.URLE(name, params)
+
+	return path
+}
+
+// URLE returns the path of a named route with wildcards replaced by params. Values are escaped,
+// the values of multi segment wildcards like {path...} keep their slashes. Wildcards left open
+// are kept as URI Template expressions, e.g. /orders/{id}, or /files/{+path} for multi segment
+// wildcards. The host and method of a pattern are not part of the path.
+func (rt *Router) URLE(name string, params map[string]string) (string, error) {
+	rt.mutex.RLock()
+	segments, ok := rt.routes[name]
+	rt.mutex.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("%w %s", ErrUnknownRoute, name)
+	}
+
+	builder := strings.Builder{}
+	used := map[string]bool{}
+
+	for _, segment := range segments {
+		builder.WriteString("/")
+
+		if segment.wildcard == "" {
+			builder.WriteString(segment.literal)
+			continue
+		}
+
+		value, ok := params[segment.wildcard]
+		used[segment.wildcard] = true
+
+		switch {
+		case !ok && segment.multiple:
+			builder.WriteString("{+" + segment.wildcard + "}")
+		case !ok:
+			builder.WriteString("{" + segment.wildcard + "}")
+		case segment.multiple:
+			parts := strings.Split(value, "/")
+
+			for i, part := range parts {
+				parts[i] = url.PathEscape(part)
+			}
+
+			builder.WriteString(strings.Join(parts, "/"))
+		default:
+			builder.WriteString(url.PathEscape(value))
+		}
+	}
+
+	unknown := []string{}
+
+	for param := range params {
+		if !used[param] {
+			unknown = append(unknown, param)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", fmt.Errorf("route %s: %w %s", name, ErrUnknownParameter, strings.Join(unknown, ", "))
+	}
+
+	return builder.String(), nil
+}
+
+// Link creates a LinkObject targeting a named route configured by provided options. A link with
+// wildcards left open is templated.
+func (rt *Router) Link(name string, params map[string]string, options ...hal.LinkOption) (*hal.LinkObject, error) {
+	path, err := rt.URLE(name, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := hal.NewLinkObject(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	link.Templated = strings.Contains(path, "{")
+
+	for _, option := range options {
+		if err := option(link); err != nil {
+			return nil, err
+		}
+	}
+
+	return link, nil
+}
+
+// parseRoute parses the path segments of a valid http.ServeMux pattern.
+func parseRoute(pattern string) []routeSegment {
+	path := pattern
+
+	if index := strings.IndexAny(path, " \t"); index >= 0 {
+		path = strings.TrimLeft(path[index:], " \t")
+	}
+
+	path = path[strings.Index(path, "/")+1:]
+	segments := []routeSegment{}
+
+	for _, segment := range strings.Split(path, "/") {
+		switch {
+		case segment == "{$}":
+			segments = append(segments, routeSegment{})
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			wildcard := strings.Trim(segment, "{}")
+			multiple := strings.HasSuffix(wildcard, "...")
+			segments = append(segments, routeSegment{wildcard: strings.TrimSuffix(wildcard, "..."), multiple: multiple})
+		default:
+			segments = append(segments, routeSegment{literal: segment})
+		}
+	}
+
+	return segments
+}
@@ -0,0 +1,120 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal"
+)
+
+func newTestRouter() *Router {
+	router := NewRouter()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("id") + "/" + r.PathValue("itemID")))
+	}
+
+	router.HandleFunc("orders", "GET /orders/{$}", handler)
+	router.HandleFunc("order", "GET /orders/{id}", handler)
+	router.HandleFunc("order-item", "GET  example.com/orders/{id}/items/{itemID}", handler)
+	router.HandleFunc("files", "/files/{path...}", handler)
+	router.HandleFunc("", "/unnamed", handler)
+
+	return router
+}
+
+func TestRouterServeHTTP(t *testing.T) {
+	router := newTestRouter()
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/orders/1/items/2", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	if body := recorder.Body.String(); body != "1/2" {
+		t.Errorf("Body is %s, want %s", body, "1/2")
+	}
+}
+
+func TestRouterURL(t *testing.T) {
+	router := newTestRouter()
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   string
+	}{
+		{"orders", nil, "/orders/"},
+		{"order", map[string]string{"id": "1"}, "/orders/1"},
+		{"order", nil, "/orders/{id}"},
+		{"order-item", map[string]string{"id": "a b", "itemID": "x/y"}, "/orders/a%20b/items/x%2Fy"},
+		{"order-item", map[string]string{"itemID": "2"}, "/orders/{id}/items/2"},
+		{"files", map[string]string{"path": "docs/a b.txt"}, "/files/docs/a%20b.txt"},
+		{"files", nil, "/files/{+path}"},
+	}
+
+	for _, test := range tests {
+		if value := router.URL(test.name, test.params); value != test.want {
+			t.Errorf("URL of %s is %s, want %s", test.name, value, test.want)
+		}
+	}
+
+	if _, err := router.URLE("unknown", nil); !errors.Is(err, ErrUnknownRoute) {
+		t.Errorf("Error is %v, want %v", err, ErrUnknownRoute)
+	}
+
+	if _, err := router.URLE("order", map[string]string{"id": "1", "name": "x"}); !errors.Is(err, ErrUnknownParameter) {
+		t.Errorf("Error is %v, want %v", err, ErrUnknownParameter)
+	}
+
+	if value := router.URL("unknown", nil); value != "" {
+		t.Errorf("URL of unknown route is %s, want empty string", value)
+	}
+}
+
+func TestRouterLink(t *testing.T) {
+	router := newTestRouter()
+
+	link, err := router.Link("order", nil, hal.Titled("Order"))
+
+	if err != nil {
+		t.Fatalf("Link returned error: %v", err)
+	}
+
+	if link.Href != "/orders/{id}" || !link.Templated || link.Title != "Order" {
+		t.Errorf("Link is %v", link)
+	}
+
+	link, _ = router.Link("order", map[string]string{"id": "1"})
+
+	if link.Href != "/orders/1" || link.Templated {
+		t.Errorf("Link is %v", link)
+	}
+
+	if _, err := router.Link("unknown", nil); !errors.Is(err, ErrUnknownRoute) {
+		t.Errorf("Error is %v, want %v", err, ErrUnknownRoute)
+	}
+
+	factory := hal.NewResourceFactory(nil)
+	relation := factory.CreateLink("item", router.URL("order-item", map[string]string{"id": "1"}), "")
+
+	if templated := relation.Links()[0]; templated.Href != "/orders/1/items/{itemID}" || !templated.Templated {
+		t.Errorf("Link is %v", templated)
+	}
+}
+
+func TestRouterHandlePanics(t *testing.T) {
+	router := newTestRouter()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Handle should panic for duplicate route name")
+		}
+	}()
+
+	router.HandleFunc("order", "/other/{id}", func(w http.ResponseWriter, r *http.Request) {})
+}
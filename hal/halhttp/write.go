@@ -0,0 +1,174 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pmoule/go2hal/hal"
+	"github.com/pmoule/go2hal/halforms"
+)
+
+// ResponderOption configures a Responder.
+type ResponderOption func(*Responder)
+
+// WithLinkHeaders makes a Responder mirror the links of provided relations into an RFC 8288 Link
+// header, e.g. for clients reading headers of HEAD requests only. Relations are identified by
+// their full name, e.g. next or doc:search. See hal.Links.LinkHeader for the header format.
+func WithLinkHeaders(relationNames ...string) ResponderOption {
+	return func(responder *Responder) {
+		responder.linkHeaders = append(responder.linkHeaders, relationNames...)
+	}
+}
+
+// Responder writes resources as content-negotiated HTTP responses.
+type Responder struct {
+	linkHeaders []string
+}
+
+// NewResponder creates a Responder configured by provided options.
+func NewResponder(options ...ResponderOption) *Responder {
+	responder := &Responder{}
+
+	for _, option := range options {
+		option(responder)
+	}
+
+	return responder
+}
+
+var defaultResponder = NewResponder()
+
+// Write writes provided Resource as HAL document with status code.
+// The media type is negotiated using the request's Accept header and set as Content-Type.
+// A Resource has no HAL-FORMS templates, so it is served as MediaTypeIdentifier to clients
+// accepting the HAL-FORMS media type only.
+// If no supported media type is acceptable, 406 Not Acceptable is answered.
+// If the Resource can't be encoded, 500 Internal Server Error is answered.
+func Write(w http.ResponseWriter, r *http.Request, status int, res hal.Resource) {
+	defaultResponder.Write(w, r, status, res)
+}
+
+// Write writes provided Resource as HAL document with status code the same way the
+// package function Write does. The Link header is added as configured.
+func (rs *Responder) Write(w http.ResponseWriter, r *http.Request, status int, res hal.Resource) {
+	w.Header().Add("Vary", "Accept")
+	mediaType, ok := negotiate(r.Header.Get("Accept"), resourceMediaTypes)
+
+	// HAL-FORMS documents are HAL documents, plain HAL is the best a HAL-FORMS client gets
+	if !ok {
+		_, ok = negotiate(r.Header.Get("Accept"), []string{halforms.MediaTypeIdentifier})
+		mediaType = MediaTypeIdentifier
+	}
+
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+		return
+	}
+
+	bytes, err := hal.NewEncoder().ToJSON(res)
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if header := rs.linkHeader(res); header != "" {
+		w.Header().Add("Link", header)
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(status)
+	w.Write(bytes)
+}
+
+// Handler adapts a function returning a Resource to an http.Handler the same way the package
+// function Handler does. Resources are written by the Responder.
+func (rs *Responder) Handler(f func(r *http.Request) (hal.Resource, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rs.serve(w, r, f)
+	})
+}
+
+// linkHeader returns the Link header value of the configured relations of a Resource.
+// Resources not implementing hal.RelationLister get no Link header.
+func (rs *Responder) linkHeader(res hal.Resource) string {
+	lister, ok := res.(hal.RelationLister)
+
+	if len(rs.linkHeaders) == 0 || !ok {
+		return ""
+	}
+
+	links := hal.Links{}
+
+	for _, relation := range lister.LinkRelations() {
+		for _, name := range rs.linkHeaders {
+			if relation.FullName() == name {
+				links[name] = relation
+			}
+		}
+	}
+
+	return links.LinkHeader()
+}
+
+// StatusError is an error carrying the HTTP status code a Handler answers with.
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+// Error returns the message of the wrapped error.
+func (e *StatusError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// HandlerFunc is a function returning the Resource to respond with.
+type HandlerFunc func(r *http.Request) (hal.Resource, error)
+
+// ServeHTTP calls f and writes the returned Resource with status 200 OK.
+// A nil Resource is answered with 204 No Content.
+// A returned *StatusError is answered with its status code, all other errors with 500 Internal Server Error.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defaultResponder.serve(w, r, f)
+}
+
+func (rs *Responder) serve(w http.ResponseWriter, r *http.Request, f func(r *http.Request) (hal.Resource, error)) {
+	res, err := f(r)
+
+	if err != nil {
+		status := http.StatusInternalServerError
+		var statusError *StatusError
+
+		if errors.As(err, &statusError) {
+			status = statusError.Code
+		}
+
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if res == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	rs.Write(w, r, http.StatusOK, res)
+}
+
+// Handler adapts a function returning a Resource to an http.Handler.
+func Handler(f func(r *http.Request) (hal.Resource, error)) http.Handler {
+	return HandlerFunc(f)
+}
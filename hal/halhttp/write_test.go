@@ -0,0 +1,173 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal"
+	"github.com/pmoule/go2hal/halforms"
+)
+
+func createTestResource() hal.Resource {
+	resource := hal.NewResourceObject()
+	self, _ := hal.NewLinkObject("/docwhoapi/doctors/1")
+	relation := hal.NewSelfLinkRelation()
+	relation.SetLink(self)
+	resource.AddLink(relation)
+	resource.Data()["name"] = "The Doctor"
+
+	return resource
+}
+
+func TestWrite(t *testing.T) {
+	resource := createTestResource()
+	wanted, _ := hal.NewEncoder().ToJSON(resource)
+	request := httptest.NewRequest(http.MethodGet, "/docwhoapi/doctors/1", nil)
+	request.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+
+	Write(recorder, request, http.StatusCreated, resource)
+
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("Status code is %d, want %d", recorder.Code, http.StatusCreated)
+	}
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != JSONMediaTypeIdentifier {
+		t.Errorf("Content-Type is %s, want %s", contentType, JSONMediaTypeIdentifier)
+	}
+
+	if body := recorder.Body.String(); body != string(wanted) {
+		t.Errorf("Body is %s, want %s", body, string(wanted))
+	}
+}
+
+func TestWriteForHALFormsClient(t *testing.T) {
+	resource := createTestResource()
+	wanted, _ := hal.NewEncoder().ToJSON(resource)
+	tests := []struct {
+		accept      string
+		contentType string
+	}{
+		{halforms.MediaTypeIdentifier, MediaTypeIdentifier},
+		{halforms.MediaTypeIdentifier + ", application/json;q=0.5", JSONMediaTypeIdentifier},
+		{halforms.MediaTypeIdentifier + ", application/hal+json;q=0.5", MediaTypeIdentifier},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/docwhoapi/doctors/1", nil)
+		request.Header.Set("Accept", test.accept)
+		recorder := httptest.NewRecorder()
+
+		Write(recorder, request, http.StatusOK, resource)
+
+		if contentType := recorder.Header().Get("Content-Type"); contentType != test.contentType {
+			t.Errorf("Content-Type for %s is %s, want %s", test.accept, contentType, test.contentType)
+		}
+
+		if body := recorder.Body.String(); body != string(wanted) {
+			t.Errorf("Body is %s, want %s", body, string(wanted))
+		}
+	}
+}
+
+func TestWriteNotAcceptable(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/docwhoapi/doctors/1", nil)
+	request.Header.Set("Accept", "text/html")
+	recorder := httptest.NewRecorder()
+
+	Write(recorder, request, http.StatusOK, createTestResource())
+
+	if recorder.Code != http.StatusNotAcceptable {
+		t.Errorf("Status code is %d, want %d", recorder.Code, http.StatusNotAcceptable)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	resource := createTestResource()
+	notFound := errors.New("doctor not found")
+	tests := []struct {
+		resource hal.Resource
+		err      error
+		want     int
+	}{
+		{resource, nil, http.StatusOK},
+		{nil, nil, http.StatusNoContent},
+		{nil, errors.New("failure"), http.StatusInternalServerError},
+		{nil, &StatusError{Code: http.StatusNotFound, Err: notFound}, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		handler := Handler(func(r *http.Request) (hal.Resource, error) {
+			return test.resource, test.err
+		})
+		request := httptest.NewRequest(http.MethodGet, "/docwhoapi/doctors/1", nil)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.want {
+			t.Errorf("Status code is %d, want %d", recorder.Code, test.want)
+		}
+	}
+
+	handler := Handler(func(r *http.Request) (hal.Resource, error) { return resource, nil })
+	request := httptest.NewRequest(http.MethodGet, "/docwhoapi/doctors/1", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != MediaTypeIdentifier {
+		t.Errorf("Content-Type is %s, want %s", contentType, MediaTypeIdentifier)
+	}
+}
+
+func TestResponderWithLinkHeaders(t *testing.T) {
+	resource := createTestResource()
+	next, _ := hal.NewLinkRelation("next")
+	next.SetLink(&hal.LinkObject{Href: "/docwhoapi/doctors/2"})
+	resource.AddLink(next)
+
+	responder := NewResponder(WithLinkHeaders("next", "prev"))
+	request := httptest.NewRequest(http.MethodGet, "/docwhoapi/doctors/1", nil)
+	recorder := httptest.NewRecorder()
+
+	responder.Write(recorder, request, http.StatusOK, resource)
+
+	if link := recorder.Header().Get("Link"); link != `</docwhoapi/doctors/2>; rel="next"` {
+		t.Errorf("Link header is %s, want %s", link, `</docwhoapi/doctors/2>; rel="next"`)
+	}
+
+	recorder = httptest.NewRecorder()
+	Write(recorder, request, http.StatusOK, resource)
+
+	if link := recorder.Header().Get("Link"); link != "" {
+		t.Errorf("Link header is %s, want no Link header", link)
+	}
+
+	handler := responder.Handler(func(r *http.Request) (hal.Resource, error) {
+		return resource, nil
+	})
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodHead, "/docwhoapi/doctors/1", nil))
+
+	if link := recorder.Header().Get("Link"); link != `</docwhoapi/doctors/2>; rel="next"` {
+		t.Errorf("Link header is %s, want %s", link, `</docwhoapi/doctors/2>; rel="next"`)
+	}
+
+	handler = responder.Handler(func(r *http.Request) (hal.Resource, error) {
+		return nil, &StatusError{Code: http.StatusNotFound}
+	})
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Status code is %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,177 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WalkLinks calls visit for each LinkObject of a Resource and its embedded resources, CURIE links
+// included. A LinkObject assigned several times is visited once. Walking stops at the first error.
+func WalkLinks(resource Resource, visit func(link *LinkObject) error) error {
+	return walkLinks(resource, visit, map[*LinkObject]bool{})
+}
+
+func walkLinks(resource Resource, visit func(link *LinkObject) error, visited map[*LinkObject]bool) error {
+	if resource == nil {
+		return nil
+	}
+
+	for _, relation := range linkRelationsOf(resource) {
+		for _, link := range relation.Links() {
+			if link == nil || visited[link] {
+				continue
+			}
+
+			visited[link] = true
+
+			if err := visit(link); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, relation := range resourceRelationsOf(resource) {
+		for _, embedded := range relation.Resources() {
+			if err := walkLinks(embedded, visit, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ResolveHrefs resolves the href of each LinkObject of a Resource and its embedded resources
+// against base, which makes relative hrefs absolute. URI Template expressions are kept unchanged.
+func ResolveHrefs(resource Resource, base *url.URL) error {
+	return WalkLinks(resource, func(link *LinkObject) error {
+		href, err := ResolveHref(base, link.Href)
+
+		if err != nil {
+			return err
+		}
+
+		link.Href = href
+		return nil
+	})
+}
+
+// RelativizeHrefs makes the href of each LinkObject of a Resource and its embedded resources
+// relative to base. Only hrefs with the scheme and authority of base are changed.
+// URI Template expressions are kept unchanged.
+func RelativizeHrefs(resource Resource, base *url.URL) error {
+	return WalkLinks(resource, func(link *LinkObject) error {
+		href, err := relativizeHref(base, link.Href)
+
+		if err != nil {
+			return err
+		}
+
+		link.Href = href
+		return nil
+	})
+}
+
+// splitTemplate splits an href into the URI reference in front of the first URI Template
+// expression and the remaining template.
+func splitTemplate(href string) (string, string) {
+	index := strings.Index(href, "{")
+
+	if index < 0 {
+		return href, ""
+	}
+
+	return href[:index], href[index:]
+}
+
+// ResolveHref resolves href against base. The part of href starting with the first URI Template
+// expression is kept unchanged.
+func ResolveHref(base *url.URL, href string) (string, error) {
+	reference, template := splitTemplate(href)
+
+	if reference == "" {
+		return href, nil
+	}
+
+	referenceURL, err := url.Parse(reference)
+
+	if err != nil {
+		return "", &LinkError{Property: "href", Value: href, Err: ErrInvalidURIRef}
+	}
+
+	return base.ResolveReference(referenceURL).String() + template, nil
+}
+
+// relativizeHref returns href relative to base. Hrefs with another scheme or authority than base,
+// and hrefs being relative already, are returned unchanged.
+func relativizeHref(base *url.URL, href string) (string, error) {
+	reference, template := splitTemplate(href)
+
+	if reference == "" {
+		return href, nil
+	}
+
+	target, err := url.Parse(reference)
+
+	if err != nil {
+		return "", &LinkError{Property: "href", Value: href, Err: ErrInvalidURIRef}
+	}
+
+	if !target.IsAbs() || !strings.EqualFold(target.Scheme, base.Scheme) || !strings.EqualFold(target.Host, base.Host) ||
+		target.User.String() != base.User.String() {
+		return href, nil
+	}
+
+	relative := relativePath(base.EscapedPath(), target.EscapedPath())
+
+	if target.ForceQuery || target.RawQuery != "" {
+		relative += "?" + target.RawQuery
+	}
+
+	if target.Fragment != "" {
+		relative += "#" + target.EscapedFragment()
+	}
+
+	return relative + template, nil
+}
+
+// relativePath returns a relative path reference from the base path to the target path.
+// Without common directory, the absolute target path is returned.
+func relativePath(basePath string, targetPath string) string {
+	if basePath == "" {
+		basePath = "/"
+	}
+
+	if targetPath == "" {
+		targetPath = "/"
+	}
+
+	baseDirs := strings.Split(basePath[:strings.LastIndex(basePath, "/")], "/")
+	targetSegments := strings.Split(targetPath, "/")
+	common := 0
+
+	for common < len(baseDirs) && common < len(targetSegments)-1 && baseDirs[common] == targetSegments[common] {
+		common++
+	}
+
+	if common <= 1 && len(baseDirs) > 1 {
+		return targetPath
+	}
+
+	relative := strings.Repeat("../", len(baseDirs)-common) + strings.Join(targetSegments[common:], "/")
+
+	if relative == "" {
+		return "./"
+	}
+
+	// a first segment with colon would be taken for a scheme
+	if first, _, _ := strings.Cut(relative, "/"); strings.Contains(first, ":") {
+		relative = "./" + relative
+	}
+
+	return relative
+}
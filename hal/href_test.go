@@ -0,0 +1,144 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newHrefTestResource() (Resource, *LinkObject) {
+	curieLink, _ := NewCurieLink("doc", "/docs/{rel}")
+	factory, _ := NewFactory(WithCurieLinks(curieLink))
+
+	root, _ := factory.RootResource("/api/doctors")
+	search, _ := factory.Link("doc:search", "/api/doctors{?name}", Templated())
+	root.AddLink(search)
+
+	doctor, _ := factory.EmbeddedResource("doctors/1")
+	doctors, _ := factory.ResourceLink("doc:doctors", doctor)
+	root.AddResource(doctors)
+
+	return root, curieLink
+}
+
+func TestWalkLinks(t *testing.T) {
+	root, _ := newHrefTestResource()
+	hrefs := []string{}
+
+	WalkLinks(root, func(link *LinkObject) error {
+		hrefs = append(hrefs, link.Href)
+		return nil
+	})
+
+	want := []string{"/api/doctors", "/docs/{rel}", "/api/doctors{?name}", "doctors/1"}
+
+	if len(hrefs) != len(want) {
+		t.Fatalf("Visited hrefs are %v, want %v", hrefs, want)
+	}
+
+	for i := range want {
+		if hrefs[i] != want[i] {
+			t.Errorf("Href is %s, want %s", hrefs[i], want[i])
+		}
+	}
+}
+
+func TestResolveAndRelativizeHrefs(t *testing.T) {
+	root, curieLink := newHrefTestResource()
+	base, _ := url.Parse("https://example.com/api/")
+
+	if err := ResolveHrefs(root, base); err != nil {
+		t.Fatalf("ResolveHrefs returned error: %v", err)
+	}
+
+	doctor := resourceRelationsOf(root)[0].Resources()[0]
+	tests := []struct {
+		link *LinkObject
+		want string
+	}{
+		{linkRelationsOf(root)[0].Links()[0], "https://example.com/api/doctors"},
+		{curieLink, "https://example.com/docs/{rel}"},
+		{linkRelationsOf(root)[2].Links()[0], "https://example.com/api/doctors{?name}"},
+		{linkRelationsOf(doctor)[0].Links()[0], "https://example.com/api/doctors/1"},
+	}
+
+	for _, test := range tests {
+		if test.link.Href != test.want {
+			t.Errorf("Resolved href is %s, want %s", test.link.Href, test.want)
+		}
+	}
+
+	if err := RelativizeHrefs(root, base); err != nil {
+		t.Fatalf("RelativizeHrefs returned error: %v", err)
+	}
+
+	relative := []string{"doctors", "/docs/{rel}", "doctors{?name}", "doctors/1"}
+
+	for i, test := range tests {
+		if test.link.Href != relative[i] {
+			t.Errorf("Relative href is %s, want %s", test.link.Href, relative[i])
+		}
+	}
+}
+
+func TestRelativizeHref(t *testing.T) {
+	base, _ := url.Parse("https://example.com/api/v1/doctors?page=1")
+
+	tests := []struct {
+		href string
+		want string
+	}{
+		{"https://example.com/api/v1/doctors", "doctors"},
+		{"https://example.com/api/v1/doctors?page=2", "doctors?page=2"},
+		{"https://EXAMPLE.com/api/v1/companions/1#name", "companions/1#name"},
+		{"https://example.com/api/v1/", "./"},
+		{"https://example.com/api/v1/?page=2", "./?page=2"},
+		{"https://example.com/api/v2/doctors", "../v2/doctors"},
+		{"https://example.com/other/doctors", "/other/doctors"},
+		{"https://example.com", "/"},
+		{"https://example.com/api/v1/a:b", "./a:b"},
+		{"https://example.com/api/v1/doctors/{id}{?fields}", "doctors/{id}{?fields}"},
+		{"http://example.com/api/v1/doctors", "http://example.com/api/v1/doctors"},
+		{"https://example.com:8443/api/v1/doctors", "https://example.com:8443/api/v1/doctors"},
+		{"/api/v1/doctors", "/api/v1/doctors"},
+		{"{+uri}", "{+uri}"},
+	}
+
+	for _, test := range tests {
+		relative, err := relativizeHref(base, test.href)
+
+		if err != nil || relative != test.want {
+			t.Errorf("Relative href of %s is %s, %v, want %s", test.href, relative, err, test.want)
+			continue
+		}
+
+		if relative != test.href && !strings.Contains(relative, "{") {
+			if resolved, _ := ResolveHref(base, relative); !equalURL(resolved, test.href) {
+				t.Errorf("Relative href %s resolves to %s, want %s", relative, resolved, test.href)
+			}
+		}
+	}
+
+	if _, err := relativizeHref(base, "https://example.com/%zz"); err == nil {
+		t.Errorf("relativizeHref should return error for invalid href")
+	}
+}
+
+func equalURL(a string, b string) bool {
+	normalize := func(value string) string {
+		parsed, _ := url.Parse(value)
+		parsed.Host = strings.ToLower(parsed.Host)
+
+		if parsed.Path == "" {
+			parsed.Path = "/"
+		}
+
+		return parsed.String()
+	}
+
+	return normalize(a) == normalize(b)
+}
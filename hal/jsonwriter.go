@@ -0,0 +1,272 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal/mapping"
+)
+
+// jsonWriter writes a Resource as HAL document property by property without creating
+// the complete mapping.NamedMap tree first.
+//
+// Unordered, all properties are written in sorted order, which is identical to the output of
+// json.Marshal for Resource.ToMap(). Ordered, "_links" is written first, followed by
+// "_embedded" and the data properties. Relations keep the order they were added and data
+// properties keep their declaration order.
+type jsonWriter struct {
+	w       io.Writer
+	options encoderOptions
+	depth   int
+	err     error
+}
+
+type jsonProperty struct {
+	name  string
+	write func()
+}
+
+// dataNamesProvider is implemented by resources knowing the order of their data properties.
+type dataNamesProvider interface {
+	orderedDataNames() []string
+}
+
+func (jw *jsonWriter) writeResource(resource Resource) {
+	data := resource.Data()
+	properties := []jsonProperty{}
+
+	linkRelations := linkRelationsOf(resource)
+	resourceRelations := resourceRelationsOf(resource)
+
+	// data properties replace reserved properties the same way Resource.ToMap() does
+	if len(linkRelations) == 0 && len(resourceRelations) == 0 {
+		properties = append(properties, jw.namedMapProperties(resource, data)...)
+	}
+
+	if len(linkRelations) > 0 {
+		if _, ok := data[LinksProperty]; !ok {
+			properties = append(properties, jsonProperty{LinksProperty, func() { jw.writeLinkRelations(linkRelations) }})
+		}
+	}
+
+	if len(resourceRelations) > 0 {
+		if _, ok := data[EmbeddedProperty]; !ok {
+			properties = append(properties, jsonProperty{EmbeddedProperty, func() { jw.writeResourceRelations(resourceRelations) }})
+		}
+	}
+
+	for _, name := range readDataNames(resource) {
+		value := data[name]
+		properties = append(properties, jsonProperty{name, func() { jw.writeValue(value) }})
+	}
+
+	jw.writeObject(properties)
+}
+
+// namedMapProperties returns the "_links" and "_embedded" properties of a Resource not listing
+// its relations, see RelationLister. Relations are written in sorted order.
+func (jw *jsonWriter) namedMapProperties(resource Resource, data mapping.PropertyMap) []jsonProperty {
+	properties := []jsonProperty{}
+
+	for _, namedMap := range []mapping.NamedMap{resource.Links(), resource.EmbeddedResources()} {
+		if _, ok := data[namedMap.Name]; ok || len(namedMap.Content) == 0 {
+			continue
+		}
+
+		content := namedMap.Content
+		properties = append(properties, jsonProperty{namedMap.Name, func() { jw.writeValue(content) }})
+	}
+
+	return properties
+}
+
+func (jw *jsonWriter) writeLinkRelations(relations []LinkRelation) {
+	properties := []jsonProperty{}
+
+	for _, relation := range relations {
+		relation := relation
+		properties = append(properties, jsonProperty{relation.FullName(), func() { jw.writeLinkRelation(relation) }})
+	}
+
+	jw.writeObject(properties)
+}
+
+func (jw *jsonWriter) writeLinkRelation(relation LinkRelation) {
+	links := relation.Links()
+
+	if relation.IsLinkSet() {
+		jw.writeArray(len(links), func(i int) { jw.writeValue(jw.link(links[i])) })
+
+		return
+	}
+
+	if len(links) > 0 {
+		jw.writeValue(jw.link(links[0]))
+	} else {
+		jw.write("null")
+	}
+}
+
+// link returns the LinkObject to write. With base URL, a copy with resolved href is returned.
+func (jw *jsonWriter) link(link *LinkObject) *LinkObject {
+	if jw.options.baseURL == nil || link == nil || jw.err != nil {
+		return link
+	}
+
+	href, err := ResolveHref(jw.options.baseURL, link.Href)
+
+	if err != nil {
+		jw.err = err
+		return link
+	}
+
+	resolved := *link
+	resolved.Href = href
+
+	return &resolved
+}
+
+func (jw *jsonWriter) writeResourceRelations(relations []ResourceRelation) {
+	properties := []jsonProperty{}
+
+	for _, relation := range relations {
+		relation := relation
+		properties = append(properties, jsonProperty{relation.FullName(), func() { jw.writeResourceRelation(relation) }})
+	}
+
+	jw.writeObject(properties)
+}
+
+func (jw *jsonWriter) writeResourceRelation(relation ResourceRelation) {
+	resources := relation.Resources()
+
+	if relation.IsResourceSet() {
+		jw.writeArray(len(resources), func(i int) { jw.writeResource(resources[i]) })
+
+		return
+	}
+
+	if len(resources) > 0 {
+		jw.writeResource(resources[0])
+	} else {
+		jw.write("null")
+	}
+}
+
+func (jw *jsonWriter) writeObject(properties []jsonProperty) {
+	if !jw.options.orderedProperties {
+		sort.SliceStable(properties, func(i, j int) bool {
+			return properties[i].name < properties[j].name
+		})
+	}
+
+	jw.write("{")
+	jw.depth++
+
+	for i, property := range properties {
+		if i > 0 {
+			jw.write(",")
+		}
+
+		jw.writeNewline()
+		jw.writeValue(property.name)
+		jw.write(":")
+
+		if jw.options.indented {
+			jw.write(" ")
+		}
+
+		property.write()
+	}
+
+	jw.depth--
+
+	if len(properties) > 0 {
+		jw.writeNewline()
+	}
+
+	jw.write("}")
+}
+
+func (jw *jsonWriter) writeArray(length int, writeElement func(int)) {
+	jw.write("[")
+	jw.depth++
+
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			jw.write(",")
+		}
+
+		jw.writeNewline()
+		writeElement(i)
+	}
+
+	jw.depth--
+
+	if length > 0 {
+		jw.writeNewline()
+	}
+
+	jw.write("]")
+}
+
+// writeNewline starts a new indented line, if indentation is enabled.
+func (jw *jsonWriter) writeNewline() {
+	if jw.options.indented {
+		jw.write("\n" + jw.options.prefix + strings.Repeat(jw.options.indent, jw.depth))
+	}
+}
+
+func (jw *jsonWriter) writeValue(value interface{}) {
+	if jw.err != nil {
+		return
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(!jw.options.disableHTMLEscape)
+
+	if jw.options.indented {
+		encoder.SetIndent(jw.options.prefix+strings.Repeat(jw.options.indent, jw.depth), jw.options.indent)
+	}
+
+	if err := encoder.Encode(value); err != nil {
+		jw.err = err
+		return
+	}
+
+	// json.Encoder terminates each value with a newline
+	_, jw.err = jw.w.Write(bytes.TrimSuffix(buffer.Bytes(), []byte("\n")))
+}
+
+func (jw *jsonWriter) write(s string) {
+	if jw.err != nil {
+		return
+	}
+
+	_, jw.err = io.WriteString(jw.w, s)
+}
+
+// readDataNames returns the data property names of a resource.
+func readDataNames(resource Resource) []string {
+	if provider, ok := resource.(dataNamesProvider); ok {
+		return provider.orderedDataNames()
+	}
+
+	names := []string{}
+
+	for name := range resource.Data() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
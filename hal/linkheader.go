@@ -0,0 +1,397 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+	"github.com/pmoule/go2hal/hal/uritemplate"
+)
+
+// ErrInvalidLinkHeader is returned by ParseLinkHeader for a malformed Link header.
+var ErrInvalidLinkHeader = errors.New("invalid Link header")
+
+// LinkHeader returns the links as value of an RFC 8288 Link header, e.g.
+// </doctors?page=2>; rel="next"; title="Next page".
+// Title, Type and HrefLang are written as title, type and hreflang parameters, templated links
+// get the extension parameter templated="true". Relations with CURIE link are written with the
+// expanded URI as relation type. The CURIEs relation itself is skipped. Relations are written
+// sorted by name.
+// See https://tools.ietf.org/html/rfc8288.
+func (l Links) LinkHeader() string {
+	names := []string{}
+
+	for name := range l {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	values := []string{}
+
+	for _, name := range names {
+		relation := l[name]
+
+		if relation == nil || relation.FullName() == relationtype.CURIES {
+			continue
+		}
+
+		rel := relationURI(relation)
+
+		for _, link := range relation.Links() {
+			if link != nil {
+				values = append(values, formatLinkValue(link, rel))
+			}
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// relationURI returns the name of a relation, the expanded URI for relations with CURIE link.
+// The full name is returned, if the CURIE link href is no template with a "rel" variable.
+func relationURI(relation Relation) string {
+	if relation.FullName() == relation.Name() {
+		return relation.Name()
+	}
+
+	template, err := uritemplate.Parse(relation.CurieLink().Href)
+
+	if err != nil || !hasVariable(template, "rel") {
+		return relation.FullName()
+	}
+
+	uri, err := template.Expand(map[string]interface{}{"rel": relation.Name()})
+
+	if err != nil {
+		return relation.FullName()
+	}
+
+	return uri
+}
+
+func hasVariable(template *uritemplate.Template, name string) bool {
+	for _, variable := range template.Variables() {
+		if variable == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatLinkValue(link *LinkObject, rel string) string {
+	builder := strings.Builder{}
+	builder.WriteString("<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(link.Href) + ">")
+	builder.WriteString("; rel=" + quote(rel))
+
+	if link.Title != "" {
+		if isQuotable(link.Title) {
+			builder.WriteString("; title=" + quote(link.Title))
+		} else {
+			// RFC 8187 extended notation for non-ASCII titles
+			builder.WriteString("; title*=" + encodeExtValue(link.Title))
+		}
+	}
+
+	if link.Type != "" {
+		builder.WriteString("; type=" + quote(link.Type))
+	}
+
+	if link.HrefLang != "" {
+		builder.WriteString("; hreflang=" + tokenOrQuote(link.HrefLang))
+	}
+
+	if link.Templated {
+		builder.WriteString(`; templated="true"`)
+	}
+
+	return builder.String()
+}
+
+func isQuotable(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if (value[i] < 0x20 && value[i] != '\t') || value[i] >= 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
+
+func tokenOrQuote(value string) string {
+	for i := 0; i < len(value); i++ {
+		if !isTokenChar(value[i]) {
+			return quote(value)
+		}
+	}
+
+	return value
+}
+
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// ParseLinkHeader parses the value of an RFC 8288 Link header into Link Relations.
+// A link with several relation types is assigned to each of them. Relations keep the order
+// of their first occurrence, relations with more than one link are structured in an array.
+// The title, title*, type, hreflang and templated parameters are assigned to the LinkObject,
+// other parameters are ignored. Links with anchor parameter have another context than the
+// requested resource and are skipped. Registered relation types are converted to lower case.
+// See https://tools.ietf.org/html/rfc8288.
+func ParseLinkHeader(header string) ([]LinkRelation, error) {
+	values, err := parseLinkValues(header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	links := map[string][]*LinkObject{}
+
+	for _, value := range values {
+		if _, ok := value.params["anchor"]; ok {
+			continue
+		}
+
+		for _, rel := range strings.Fields(value.params["rel"]) {
+			if relationtype.IsRegistered(rel) {
+				rel = strings.ToLower(rel)
+			}
+
+			if _, ok := links[rel]; !ok {
+				names = append(names, rel)
+			}
+
+			link := *value.link
+			links[rel] = append(links[rel], &link)
+		}
+	}
+
+	relations := []LinkRelation{}
+
+	for _, name := range names {
+		relation, _ := NewLinkRelation(name)
+
+		if len(links[name]) == 1 {
+			relation.SetLink(links[name][0])
+		} else {
+			relation.SetLinks(links[name])
+		}
+
+		relations = append(relations, relation)
+	}
+
+	return relations, nil
+}
+
+type linkValue struct {
+	link   *LinkObject
+	params map[string]string
+}
+
+// parseLinkValues parses all link values of a Link header. Only the first occurrence of a
+// parameter is used.
+func parseLinkValues(header string) ([]linkValue, error) {
+	parser := &linkHeaderParser{input: header}
+	values := []linkValue{}
+
+	for {
+		parser.skip(" \t,")
+
+		if parser.done() {
+			return values, nil
+		}
+
+		value, err := parser.parseLinkValue()
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s at position %d", ErrInvalidLinkHeader, err, parser.position)
+		}
+
+		values = append(values, value)
+		parser.skip(" \t")
+
+		if !parser.done() && parser.input[parser.position] != ',' {
+			return nil, fmt.Errorf("%w: missing comma at position %d", ErrInvalidLinkHeader, parser.position)
+		}
+	}
+}
+
+type linkHeaderParser struct {
+	input    string
+	position int
+}
+
+func (p *linkHeaderParser) done() bool {
+	return p.position >= len(p.input)
+}
+
+func (p *linkHeaderParser) skip(chars string) {
+	for !p.done() && strings.IndexByte(chars, p.input[p.position]) >= 0 {
+		p.position++
+	}
+}
+
+func (p *linkHeaderParser) parseLinkValue() (linkValue, error) {
+	if p.input[p.position] != '<' {
+		return linkValue{}, errors.New("missing <")
+	}
+
+	end := strings.IndexByte(p.input[p.position:], '>')
+
+	if end < 0 {
+		return linkValue{}, errors.New("missing >")
+	}
+
+	value := linkValue{link: &LinkObject{Href: strings.TrimSpace(p.input[p.position+1 : p.position+end])}, params: map[string]string{}}
+	p.position += end + 1
+
+	for {
+		p.skip(" \t")
+
+		if p.done() || p.input[p.position] != ';' {
+			break
+		}
+
+		p.position++
+		p.skip(" \t")
+		name := strings.ToLower(p.parseToken())
+
+		if name == "" {
+			return linkValue{}, errors.New("missing parameter name")
+		}
+
+		p.skip(" \t")
+		paramValue := ""
+
+		if !p.done() && p.input[p.position] == '=' {
+			p.position++
+			p.skip(" \t")
+
+			var err error
+
+			if paramValue, err = p.parseParamValue(); err != nil {
+				return linkValue{}, err
+			}
+		}
+
+		if _, ok := value.params[name]; !ok {
+			value.params[name] = paramValue
+		}
+	}
+
+	if _, ok := value.params["rel"]; !ok {
+		return linkValue{}, errors.New("missing rel parameter")
+	}
+
+	value.link.Type = value.params["type"]
+	value.link.HrefLang = value.params["hreflang"]
+	value.link.Templated = strings.EqualFold(value.params["templated"], "true")
+	value.link.Title = value.params["title"]
+
+	if title, ok := decodeExtValue(value.params["title*"]); ok {
+		value.link.Title = title
+	}
+
+	return value, nil
+}
+
+func (p *linkHeaderParser) parseToken() string {
+	start := p.position
+
+	for !p.done() && isTokenChar(p.input[p.position]) {
+		p.position++
+	}
+
+	return p.input[start:p.position]
+}
+
+func (p *linkHeaderParser) parseParamValue() (string, error) {
+	if p.done() || p.input[p.position] != '"' {
+		return p.parseToken(), nil
+	}
+
+	builder := strings.Builder{}
+
+	for p.position++; !p.done(); p.position++ {
+		switch c := p.input[p.position]; c {
+		case '"':
+			p.position++
+			return builder.String(), nil
+		case '\\':
+			p.position++
+
+			if p.done() {
+				return "", errors.New("unterminated quoted string")
+			}
+
+			builder.WriteByte(p.input[p.position])
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return "", errors.New("unterminated quoted string")
+}
+
+func isTokenChar(c byte) bool {
+	return c > 0x20 && c < 0x7f && !strings.ContainsRune(`"(),/:;<=>?@[\]{}`, rune(c))
+}
+
+// encodeExtValue encodes a value as RFC 8187 ext-value with UTF-8 charset.
+func encodeExtValue(value string) string {
+	builder := strings.Builder{}
+	builder.WriteString("UTF-8''")
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || strings.IndexByte("!#$&+-.^_`|~", c) >= 0 {
+			builder.WriteByte(c)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", c)
+		}
+	}
+
+	return builder.String()
+}
+
+// decodeExtValue decodes an RFC 8187 ext-value like UTF-8'en'%E2%82%AC%20rates.
+func decodeExtValue(value string) (string, bool) {
+	parts := strings.SplitN(value, "'", 3)
+
+	if len(parts) != 3 {
+		return "", false
+	}
+
+	decoded, err := url.PathUnescape(parts[2])
+
+	if err != nil {
+		return "", false
+	}
+
+	switch strings.ToUpper(parts[0]) {
+	case "UTF-8":
+		return decoded, utf8.ValidString(decoded)
+	case "ISO-8859-1":
+		runes := make([]rune, len(decoded))
+
+		for i := 0; i < len(decoded); i++ {
+			runes[i] = rune(decoded[i])
+		}
+
+		return string(runes), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,153 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+func TestLinkHeader(t *testing.T) {
+	curieLink, _ := NewCurieLink("doc", "http://example.com/docs/{rel}")
+	factory, _ := NewFactory(WithCurieLinks(curieLink))
+
+	root, _ := factory.RootResource("/doctors")
+	next, _ := factory.Link(relationtype.Next, "/doctors?page=2", Titled(`Next "page"`), OfType("application/hal+json"))
+	next.Links()[0].HrefLang = "en"
+	search, _ := factory.Link("doc:search", "/doctors{?name}", Titled("Suche nach Ärzten"))
+	root.AddLink(next)
+	root.AddLink(search)
+
+	links := Links{}
+
+	for _, relation := range linkRelationsOf(root) {
+		links[relation.FullName()] = relation
+	}
+
+	wanted := `</doctors{?name}>; rel="http://example.com/docs/search"; title*=UTF-8''Suche%20nach%20%C3%84rzten; templated="true", ` +
+		`</doctors?page=2>; rel="next"; title="Next \"page\""; type="application/hal+json"; hreflang=en, ` +
+		`</doctors>; rel="self"`
+
+	if header := links.LinkHeader(); header != wanted {
+		t.Errorf("Link header is %s, want %s", header, wanted)
+	}
+
+	if header := (Links{}).LinkHeader(); header != "" {
+		t.Errorf("Link header is %s, want empty string", header)
+	}
+}
+
+func TestLinkHeaderWithUntemplatedCurie(t *testing.T) {
+	curieLink, _ := NewCurieLink("doc", "http://example.com/docs/")
+	factory, _ := NewFactory(WithCurieLinks(curieLink))
+
+	root, _ := factory.RootResource("/doctors")
+	search, _ := factory.Link("doc:search", "/doctors/search")
+	root.AddLink(search)
+
+	links := Links{}
+
+	for _, relation := range linkRelationsOf(root) {
+		links[relation.FullName()] = relation
+	}
+
+	wanted := `</doctors/search>; rel="doc:search", </doctors>; rel="self"`
+
+	if header := links.LinkHeader(); header != wanted {
+		t.Errorf("Link header is %s, want %s", header, wanted)
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `</doctors?page=2>; rel="Next"; title="Next \"page\""; type="application/hal+json"; hreflang=en, ` +
+		`</doctors{?name}>; rel="http://example.com/docs/search"; title*=UTF-8''Suche%20nach%20%C3%84rzten; templated="true",` +
+		`<http://example.com/doctors/1>;rel="item up";title="first";title="ignored",` +
+		`</doctors/2>; rel=item; title*=iso-8859-1'de'%C4rzte, ` +
+		`</other>; rel="next"; anchor="#other"`
+
+	relations, err := ParseLinkHeader(header)
+
+	if err != nil {
+		t.Fatalf("ParseLinkHeader returned error: %v", err)
+	}
+
+	names := []string{relationtype.Next, "http://example.com/docs/search", relationtype.Item, relationtype.Up}
+
+	if len(relations) != len(names) {
+		t.Fatalf("Relations count is %d, want %d", len(relations), len(names))
+	}
+
+	for i, name := range names {
+		if relations[i].Name() != name {
+			t.Errorf("Relation name is %s, want %s", relations[i].Name(), name)
+		}
+	}
+
+	next := *relations[0].Links()[0]
+	wantedNext := LinkObject{Href: "/doctors?page=2", Title: `Next "page"`, Type: "application/hal+json", HrefLang: "en"}
+
+	if next != wantedNext {
+		t.Errorf("Link is %v, want %v", next, wantedNext)
+	}
+
+	search := relations[1].Links()[0]
+
+	if !search.Templated || search.Title != "Suche nach Ärzten" {
+		t.Errorf("Link is %v, want templated link with decoded title", search)
+	}
+
+	items := relations[2]
+
+	if !items.IsLinkSet() || len(items.Links()) != 2 || items.Links()[0].Title != "first" || items.Links()[1].Title != "Ärzte" {
+		t.Errorf("Item links are %v", items.Links())
+	}
+
+	if relations[3].IsLinkSet() || relations[3].Links()[0] == items.Links()[0] {
+		t.Errorf("Up relation should have its own single link")
+	}
+
+	invalid := []string{
+		`/doctors; rel="next"`,
+		`</doctors; rel="next"`,
+		`</doctors>; title="no rel"`,
+		`</doctors>; rel="next" </other>; rel="prev"`,
+		`</doctors>; rel="next; title="x"`,
+		`</doctors>; ="next"`,
+	}
+
+	for _, value := range invalid {
+		if _, err := ParseLinkHeader(value); !errors.Is(err, ErrInvalidLinkHeader) {
+			t.Errorf("Error of %s is %v, want %v", value, err, ErrInvalidLinkHeader)
+		}
+	}
+}
+
+func TestLinkHeaderRoundTrip(t *testing.T) {
+	factory, _ := NewFactory()
+	links := Links{}
+
+	for _, name := range []string{relationtype.First, relationtype.Last, relationtype.Search} {
+		relation, _ := factory.Link(name, "/doctors/"+name+"{?q}", Titled("Ärzte; "+name), OfType("text/html"))
+		links[name] = relation
+	}
+
+	relations, err := ParseLinkHeader(links.LinkHeader())
+
+	if err != nil {
+		t.Fatalf("ParseLinkHeader returned error: %v", err)
+	}
+
+	if len(relations) != len(links) {
+		t.Fatalf("Relations count is %d, want %d", len(relations), len(links))
+	}
+
+	for _, relation := range relations {
+		if *relation.Links()[0] != *links[relation.Name()].Links()[0] {
+			t.Errorf("Link is %v, want %v", *relation.Links()[0], *links[relation.Name()].Links()[0])
+		}
+	}
+}
@@ -4,7 +4,11 @@
 
 package hal
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/pmoule/go2hal/hal/uritemplate"
+)
 
 // LinkObject is a hyperlink from the Resource it is attached to.
 // A valid LinkObject requires a href value. All other properties are optional.
@@ -30,20 +34,63 @@ func NewLinkObject(href string) (*LinkObject, error) {
 	return &LinkObject{Href: href}, nil
 }
 
+// NewTemplatedLinkObject initializes a LinkObject with a href value being a URI Template.
+// See https://tools.ietf.org/html/rfc6570.
+func NewTemplatedLinkObject(href string) (*LinkObject, error) {
+	linkObject, error := NewLinkObject(href)
+
+	if error != nil {
+		return nil, error
+	}
+
+	if _, error := uritemplate.Parse(href); error != nil {
+		return nil, error
+	}
+
+	linkObject.Templated = true
+
+	return linkObject, nil
+}
+
 // NewCurieLink initializes a special LinkObject required for establishing CURIEs.
 func NewCurieLink(name string, href string) (*LinkObject, error) {
 	if name == "" {
 		return nil, errors.New("CURIE LinkObject requires a name value")
 	}
 
-	linkObject, error := NewLinkObject(href)
+	linkObject, error := NewTemplatedLinkObject(href)
 
 	if error != nil {
 		return nil, error
 	}
 
 	linkObject.Name = name
-	linkObject.Templated = true
 
 	return linkObject, nil
 }
+
+// Expand returns the href with all URI Template expressions expanded by provided values.
+// The href of a LinkObject not being templated is returned unchanged.
+func (l *LinkObject) Expand(values map[string]interface{}) (string, error) {
+	if !l.Templated {
+		return l.Href, nil
+	}
+
+	return uritemplate.Expand(l.Href, values)
+}
+
+// Variables returns the variable names of a templated href. Nil is returned for
+// a LinkObject not being templated or a malformed URI Template.
+func (l *LinkObject) Variables() []string {
+	if !l.Templated {
+		return nil
+	}
+
+	template, err := uritemplate.Parse(l.Href)
+
+	if err != nil {
+		return nil
+	}
+
+	return template.Variables()
+}
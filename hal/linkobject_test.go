@@ -38,3 +38,65 @@ func TestNewCurieLink(t *testing.T) {
 		t.Errorf("NewCurieLink should return an error due to an invalid href value.")
 	}
 }
+
+func TestNewTemplatedLinkObject(t *testing.T) {
+	wantedHref := "/docwhoapi/doctors{?page,size}"
+	link, err := NewTemplatedLinkObject(wantedHref)
+
+	if err != nil {
+		t.Fatalf("NewTemplatedLinkObject returns error: %s", err)
+	}
+
+	if link.Href != wantedHref || !link.Templated {
+		t.Errorf("Link == %+v, want templated href %q", link, wantedHref)
+	}
+
+	invalidHrefs := []string{"", "/docwhoapi/doctors{?page", "/docwhoapi/doctors{=page}"}
+
+	for _, href := range invalidHrefs {
+		if _, err := NewTemplatedLinkObject(href); err == nil {
+			t.Errorf("NewTemplatedLinkObject should return an error for %q", href)
+		}
+	}
+
+	if _, err := NewCurieLink("doc", "http://example.com/{rel"); err == nil {
+		t.Errorf("NewCurieLink should return an error due to a malformed template.")
+	}
+}
+
+func TestLinkObjectExpand(t *testing.T) {
+	link, _ := NewTemplatedLinkObject("/docwhoapi/doctors{/id}{?fields*}")
+	values := map[string]interface{}{"id": 1, "fields": []string{"name", "actor"}}
+	wanted := "/docwhoapi/doctors/1?fields=name&fields=actor"
+
+	href, err := link.Expand(values)
+
+	if err != nil {
+		t.Fatalf("Expand returns error: %s", err)
+	}
+
+	if href != wanted {
+		t.Errorf("Expanded href == %q, want %q", href, wanted)
+	}
+
+	if variables := link.Variables(); len(variables) != 2 || variables[0] != "id" || variables[1] != "fields" {
+		t.Errorf("Variables == %v, want %v", variables, []string{"id", "fields"})
+	}
+
+	link = &LinkObject{Href: "/docwhoapi/doctors{/id}"}
+
+	if href, _ := link.Expand(values); href != link.Href {
+		t.Errorf("Expanded href == %q, want %q", href, link.Href)
+	}
+
+	if variables := link.Variables(); variables != nil {
+		t.Errorf("Variables == %v, want %v", variables, nil)
+	}
+
+	link.Templated = true
+	link.Href = "/docwhoapi/doctors{/id"
+
+	if _, err := link.Expand(values); err == nil {
+		t.Errorf("Expand should return an error due to a malformed template.")
+	}
+}
@@ -178,8 +178,7 @@ func (er embeddedResources) ToMap() mapping.NamedMap {
 		var properties = []mapping.PropertyMap{}
 
 		for _, resource := range resources {
-			resourceObject := resource.(*resourceObject)
-			namedMap := resourceObject.ToMap()
+			namedMap := resource.ToMap()
 			properties = append(properties, namedMap.Content)
 		}
 
@@ -0,0 +1,300 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+package mapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type zeroByMethod struct {
+	Value int `json:"value"`
+}
+
+func (z zeroByMethod) IsZero() bool {
+	return z.Value < 0
+}
+
+type zeroByPointerMethod struct {
+	Value int `json:"value"`
+}
+
+func (z *zeroByPointerMethod) IsZero() bool {
+	return z.Value == 42
+}
+
+type textID int
+
+func (id textID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("id-%d", id)), nil
+}
+
+type pointerText string
+
+func (p *pointerText) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(*p))), nil
+}
+
+type rawNumber int
+
+func (r rawNumber) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(" %d ", r)), nil
+}
+
+type pointerRaw int
+
+func (p *pointerRaw) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"raw": %d}`, *p)), nil
+}
+
+type inner struct {
+	Name  string `json:"name"`
+	Depth int
+}
+
+type Named struct {
+	Name string `json:"name"`
+}
+
+type conflictA struct {
+	X string
+	Y string `json:"y"`
+}
+
+type conflictB struct {
+	X string
+	Y string
+}
+
+type innerText string
+
+type IntAlias int
+
+type hidden struct {
+	Visible string `json:"visible"`
+	secret  string
+}
+
+type deep struct {
+	Named
+	Level int `json:"level"`
+}
+
+// conformanceValues covers struct shapes, whose MapData result has to encode
+// identically to encoding/json.
+func conformanceValues() []interface{} {
+	number := 5
+	text := pointerText("pointer")
+	raw := pointerRaw(7)
+	now := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	return []interface{}{
+		struct {
+			A string
+			B int    `json:""`
+			C bool   `json:",omitempty"`
+			D string `json:"-,"`
+			E string `json:"-"`
+			G string `json:"ok-name!"`
+			h string
+		}{A: "a", B: 1, D: "dash", E: "e", G: "g", h: "h"},
+		struct {
+			A int     `json:"a,string"`
+			B bool    `json:"b,string"`
+			C float64 `json:"c,string"`
+			D string  `json:"d,string"`
+			E *int    `json:"e,string"`
+			F *int    `json:"f,string"`
+			G uint8   `json:"g,omitempty,string"`
+			H []int   `json:"h,string"`
+			I textID  `json:"i,string"`
+		}{A: 1, B: true, C: 1.5, D: `say "hi"`, E: &number, H: []int{1}, I: 3},
+		struct {
+			A int                 `json:"a,omitempty"`
+			B string              `json:"b,omitempty"`
+			C []int               `json:"c,omitempty"`
+			D map[string]int      `json:"d,omitempty"`
+			E *int                `json:"e,omitempty"`
+			F interface{}         `json:"f,omitempty"`
+			G [0]int              `json:"g,omitempty"`
+			H [1]int              `json:"h,omitempty"`
+			I inner               `json:"i,omitempty"`
+			J time.Time           `json:"j,omitempty"`
+			K float64             `json:"k,omitempty"`
+			L []int               `json:"l,omitempty"`
+			M map[string]struct{} `json:"m,omitempty"`
+		}{C: []int{}, D: map[string]int{}, L: []int{0}, M: map[string]struct{}{"x": {}}},
+		struct {
+			A int                  `json:"a,omitzero"`
+			B inner                `json:"b,omitzero"`
+			C time.Time            `json:"c,omitzero"`
+			D time.Time            `json:"d,omitzero"`
+			E zeroByMethod         `json:"e,omitzero"`
+			F zeroByMethod         `json:"f,omitzero"`
+			G zeroByPointerMethod  `json:"g,omitzero"`
+			H *zeroByPointerMethod `json:"h,omitzero"`
+			I []int                `json:"i,omitzero"`
+			J []int                `json:"j,omitzero"`
+			K *int                 `json:"k,omitzero"`
+			L interface{}          `json:"l,omitzero"`
+			M [2]int               `json:"m,omitzero"`
+			N string               `json:"n,omitempty,omitzero"`
+		}{D: now, E: zeroByMethod{-1}, F: zeroByMethod{1}, G: zeroByPointerMethod{42}, H: &zeroByPointerMethod{42}, J: []int{}},
+		struct {
+			Named
+			*inner
+			Own string `json:"own"`
+		}{Named: Named{"named"}, inner: &inner{Name: "inner", Depth: 1}, Own: "own"},
+		struct {
+			*inner
+			Named
+		}{},
+		struct {
+			conflictA
+			conflictB
+		}{conflictA{"ax", "ay"}, conflictB{"bx", "by"}},
+		struct {
+			conflictA
+			X string
+		}{conflictA{"ax", "ay"}, "x"},
+		struct {
+			deep
+			Name string `json:"name"`
+		}{deep{Named{"deep"}, 2}, "outer"},
+		struct {
+			deep
+			Named
+		}{deep{Named{"deep"}, 2}, Named{"named"}},
+		struct {
+			Named `json:"named"`
+			hidden
+		}{Named{"tagged"}, hidden{"visible", "secret"}},
+		struct {
+			IntAlias
+			innerText
+			Own string `json:"own"`
+		}{5, "text", "own"},
+		struct {
+			A textID                  `json:"a"`
+			B *textID                 `json:"b"`
+			C []textID                `json:"c"`
+			D pointerText             `json:"d"`
+			E *pointerText            `json:"e"`
+			F []pointerText           `json:"f"`
+			G rawNumber               `json:"g"`
+			H []rawNumber             `json:"h"`
+			I pointerRaw              `json:"i"`
+			J *pointerRaw             `json:"j"`
+			K []*pointerRaw           `json:"k"`
+			L map[textID]string       `json:"l"`
+			M map[string]pointerRaw   `json:"m"`
+			N [2]pointerRaw           `json:"n"`
+			O []*textID               `json:"o"`
+			P interface{}             `json:"p"`
+			Q json.RawMessage         `json:"q"`
+			R map[string]*pointerText `json:"r"`
+		}{
+			A: 1, B: new(textID), C: []textID{2, 3}, D: "value", E: &text, F: []pointerText{"a"},
+			G: 4, H: []rawNumber{5}, I: 6, J: &raw, K: []*pointerRaw{&raw, nil},
+			L: map[textID]string{9: "nine"}, M: map[string]pointerRaw{"m": 8}, N: [2]pointerRaw{1, 2},
+			O: []*textID{nil}, P: rawNumber(10), Q: json.RawMessage(`{"q": true}`),
+			R: map[string]*pointerText{"r": &text},
+		},
+		struct {
+			A []byte            `json:"a"`
+			B []byte            `json:"b"`
+			C []int             `json:"c"`
+			D map[string]int    `json:"d"`
+			E []inner           `json:"e"`
+			F []*inner          `json:"f"`
+			G [2]inner          `json:"g"`
+			H [][]inner         `json:"h"`
+			I map[string]inner  `json:"i"`
+			J []interface{}     `json:"j"`
+			K interface{}       `json:"k"`
+			L *inner            `json:"l"`
+			M **inner           `json:"m"`
+			N []time.Time       `json:"n"`
+			O time.Time         `json:"o"`
+			P *time.Time        `json:"p"`
+			Q map[string][]byte `json:"q"`
+		}{
+			A: []byte("bytes"), E: []inner{{"e", 1}}, F: []*inner{nil, {"f", 2}}, H: [][]inner{{{"h", 3}}},
+			I: map[string]inner{"i": {"i", 4}}, J: []interface{}{1, "j", inner{"j", 5}, nil}, K: inner{"k", 6},
+			N: []time.Time{now}, O: now, P: &now, Q: map[string][]byte{"q": []byte("q")},
+		},
+	}
+}
+
+func normalizeJSON(t *testing.T, value interface{}) interface{} {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		t.Fatalf("Marshal returns error: %s", err)
+	}
+
+	var result interface{}
+
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Unmarshal returns error: %s", err)
+	}
+
+	return result
+}
+
+func TestMapDataConformance(t *testing.T) {
+	for i, value := range conformanceValues() {
+		pointer := reflect.New(reflect.TypeOf(value))
+		pointer.Elem().Set(reflect.ValueOf(value))
+
+		// addressable and not addressable values
+		for _, data := range []interface{}{value, pointer.Interface()} {
+			wanted := normalizeJSON(t, data)
+			result := normalizeJSON(t, MapData(data))
+
+			if !reflect.DeepEqual(result, wanted) {
+				t.Errorf("Value %d (%T) is %v, want %v", i, data, result, wanted)
+			}
+		}
+	}
+}
+
+func TestMapDataConformanceWithErrors(t *testing.T) {
+	type Test1 struct {
+		A func()           `json:"a"`
+		B chan int         `json:"b"`
+		C complex128       `json:"c"`
+		D float64          `json:"d"`
+		E CustomType3      `json:"e"`
+		F invalidJSON      `json:"f"`
+		G string           `json:"g"`
+		H map[string]inner `json:"h"`
+	}
+
+	values := []Test1{
+		{A: func() {}},
+		{B: make(chan int)},
+		{C: 1},
+		{E: "error"},
+		{F: "{"},
+	}
+
+	for _, value := range values {
+		if _, err := json.Marshal(value); err == nil {
+			t.Fatalf("Marshal should return an error for %v", value)
+		}
+
+		if _, err := mapData(value); err == nil {
+			t.Errorf("mapData should return an error for %v", value)
+		}
+
+		if data := MapData(value); data["g"] != "" {
+			t.Errorf("Encodable fields should be mapped for %v", value)
+		}
+	}
+}
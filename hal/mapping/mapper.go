@@ -4,10 +4,15 @@
 package mapping
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
+	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
-	"time"
 )
 
 // NamedMap simply links a name with PropertyMap
@@ -19,263 +24,462 @@ type NamedMap struct {
 // PropertyMap simply maps a string to any kind of value.
 type PropertyMap map[string]interface{}
 
+// halTagName is the name of the struct tag marking fields as links or embedded
+// resources. These fields are no data properties.
+const halTagName = "hal"
+
+// FieldError describes a field not encodable as JSON.
+type FieldError struct {
+	// Path of the field starting with the struct type name, e.g. Order.Lines[3].Price.
+	Path string
+	Err  error
+}
+
+// Error returns the field path and the cause.
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the cause.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// withPath prepends a path segment to the path of a *FieldError or wraps err into a *FieldError.
+func withPath(err error, segment string) error {
+	if fieldError, ok := err.(*FieldError); ok {
+		return &FieldError{Path: segment + fieldError.Path, Err: fieldError.Err}
+	}
+
+	return &FieldError{Path: segment, Err: err}
+}
+
 // MapData returns a PropertyMap for provided data.
+// Fields are selected and named the same way encoding/json does, including the
+// omitempty, omitzero and string options of the json struct tag.
+// Fields with a hal struct tag are skipped. Fields not encodable as JSON are skipped as well,
+// use MapDataE to get notified.
+// Structs implementing PropertyMapper are mapped by ToPropertyMap instead of reflection.
 func MapData(data interface{}) PropertyMap {
-	return readDataFields(reflect.ValueOf(data))
+	propertyMap, _ := mapData(data)
+
+	return propertyMap
+}
+
+// MapDataE returns a PropertyMap for provided data the same way MapData does.
+// If a field is not encodable as JSON, e.g. a MarshalJSON method fails, a *FieldError
+// with the path of the first failing field is returned.
+func MapDataE(data interface{}) (PropertyMap, error) {
+	propertyMap, err := mapData(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return propertyMap, nil
+}
+
+// MapDataInOrder returns a PropertyMap for provided data and its property names
+// in field declaration order.
+func MapDataInOrder(data interface{}) (PropertyMap, []string) {
+	propertyMap, _ := mapData(data)
+
+	return propertyMap, readNamesInOrder(data, propertyMap)
+}
+
+// MapDataInOrderE returns a PropertyMap for provided data and its property names
+// in field declaration order. Errors are returned the same way MapDataE does.
+func MapDataInOrderE(data interface{}) (PropertyMap, []string, error) {
+	propertyMap, err := MapDataE(data)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return propertyMap, readNamesInOrder(data, propertyMap), nil
 }
 
-func readDataFields(v reflect.Value) PropertyMap {
-	if isZeroValue(v) {
-		if v == reflect.Zero(reflect.TypeOf(v)).Interface() {
-			return PropertyMap{}
+// readNamesInOrder returns the names of a PropertyMap in field declaration order of data.
+// Names not belonging to a field follow in sorted order.
+func readNamesInOrder(data interface{}, propertyMap PropertyMap) []string {
+	names := []string{}
+	known := map[string]bool{}
+
+	if vType := structType(data); vType != nil {
+		for _, name := range cachedTypePlan(vType).names {
+			if _, ok := propertyMap[name]; ok && !known[name] {
+				known[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	remaining := []string{}
+
+	for name := range propertyMap {
+		if !known[name] {
+			remaining = append(remaining, name)
 		}
 	}
 
-	vType := v.Type()
+	sort.Strings(remaining)
+
+	return append(names, remaining...)
+}
+
+// structType returns the struct type of data or of the value data points to.
+func structType(data interface{}) reflect.Type {
+	if data == nil {
+		return nil
+	}
+
+	vType := reflect.TypeOf(data)
 
-	// force the real type
 	if vType.Kind() == reflect.Ptr {
 		vType = vType.Elem()
-		v = v.Elem()
 	}
 
 	if vType.Kind() != reflect.Struct {
-		return PropertyMap{}
+		return nil
 	}
 
-	propertyMap := PropertyMap{}
+	return vType
+}
 
-	for i := 0; i < vType.NumField(); i++ {
-		vField := v.Field(i)
-		tField := vType.Field(i)
+// mapData returns a PropertyMap for provided data and the first error of fields
+// not encodable as JSON.
+func mapData(data interface{}) (PropertyMap, error) {
+	v := reflect.ValueOf(data)
 
-		// force the real type
-		if vField.Kind() == reflect.Ptr {
-			vField = vField.Elem()
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return PropertyMap{}, nil
 		}
 
-		if !vField.IsValid() {
-			fieldName, omitEmpty, ok := readJSONInfo(tField)
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return PropertyMap{}, nil
+	}
 
-			if !ok || omitEmpty {
-				continue
-			}
+	propertyMap, err := mapStructValue(v)
 
-			propertyMap[fieldName] = nil
-			continue
-		}
+	if err != nil {
+		err = withPath(err, v.Type().Name())
 
-		if !vField.CanInterface() {
-			continue
+		// anonymous structs have no type name
+		if fieldError := err.(*FieldError); strings.HasPrefix(fieldError.Path, ".") {
+			fieldError.Path = fieldError.Path[1:]
 		}
+	}
 
-		if tField.Anonymous {
-			value := readEmbeddedField(vField)
+	return propertyMap, err
+}
 
-			for key, v := range value {
-				propertyMap[key] = v
-			}
+// mapStructValue maps a struct by its PropertyMapper, if implemented, or by its fields.
+func mapStructValue(v reflect.Value) (PropertyMap, error) {
+	mapper, ok := findPropertyMapper(v)
 
-			continue
-		}
+	if !ok {
+		return mapStruct(v)
+	}
 
-		if fieldName, value, ok := toJSONValue(tField, vField); ok {
-			propertyMap[fieldName] = value
-		}
+	propertyMap, err := mapper.ToPropertyMap()
+
+	if propertyMap == nil {
+		propertyMap = PropertyMap{}
 	}
 
-	return propertyMap
+	return propertyMap, err
 }
 
-func readJSONInfo(tField reflect.StructField) (string, bool, bool) {
-	jsonValue, ok := tField.Tag.Lookup("json")
+// findPropertyMapper returns the PropertyMapper of a struct value. Methods with pointer receiver
+// are used for addressable values only, the same way findMarshaler does.
+func findPropertyMapper(v reflect.Value) (PropertyMapper, bool) {
+	info := cachedTypeInfo(v.Type())
 
-	if !ok || jsonValue == "-" {
-		return "", true, false
+	if info.isMapper {
+		return v.Interface().(PropertyMapper), true
 	}
 
-	tokens := strings.Split(jsonValue, ",")
-	omitEmpty := len(tokens) > 1 && strings.TrimSpace(tokens[1]) == "omitempty"
-	fieldName := tokens[0]
+	if info.isAddrMapper && v.CanAddr() {
+		return v.Addr().Interface().(PropertyMapper), true
+	}
 
-	return fieldName, omitEmpty, true
+	return nil, false
 }
 
-func toJSONValue(tField reflect.StructField, vField reflect.Value) (string, interface{}, bool) {
-	fieldName, omitEmpty, ok := readJSONInfo(tField)
+// mapStruct maps all fields of a struct. Fields not encodable as JSON are skipped and the first
+// error is returned.
+func mapStruct(v reflect.Value) (PropertyMap, error) {
+	propertyMap := PropertyMap{}
+	var firstErr error
+	plan := cachedTypePlan(v.Type())
 
-	if !ok {
-		return "", nil, false
-	}
+	for i := range plan.fields {
+		field := &plan.fields[i]
+		vField, ok := fieldByIndex(v, field.index)
 
-	_, isTime := vField.Interface().(time.Time)
+		if !ok {
+			continue
+		}
 
-	if !isTime {
-		if vField.Kind() == reflect.Slice {
-			element := tField.Type.Elem()
+		if (field.omitEmpty && isEmptyValue(vField)) || (field.omitZero && field.isZero(vField)) {
+			continue
+		}
 
-			// force the real type
-			if element.Kind() == reflect.Ptr {
-				element = element.Elem()
-			}
+		value, err := field.mapValue(vField)
 
-			if element.Kind() == reflect.Struct {
-				sliceValuePtr := createSlice([]PropertyMap{})
+		if err != nil && firstErr == nil {
+			firstErr = withPath(err, "."+field.goName)
+		}
 
-				for i := 0; i < vField.Len(); i++ {
-					v := vField.Index(i)
-					value := readDataFields(v)
-					sliceValuePtr.Set(reflect.Append(sliceValuePtr, reflect.ValueOf(value)))
-				}
+		// partially mapped structs and slices are kept
+		if err != nil && value == nil {
+			continue
+		}
 
-				return fieldName, sliceValuePtr.Interface(), true
-			} else {
-				sliceValuePtr := createSlice([]string{})
+		propertyMap[field.name] = value
+	}
 
-				for i := 0; i < vField.Len(); i++ {
-					v := vField.Index(i)
-					vType := v.Type()
+	return propertyMap, firstErr
+}
 
-					if vType.Kind() == reflect.Ptr {
-						v = v.Elem()
-					}
+// fieldByIndex returns the field of an index path. If an embedded struct pointer is nil, false is returned.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
 
-					if m, ok := v.Addr().Interface().(json.Marshaler); ok {
-						b, err := m.MarshalJSON()
+			v = v.Elem()
+		}
 
-						if err != nil {
-							continue
-						}
+		v = v.Field(i)
+	}
 
-						value := string(b)
-						isZeroValue := len(value) == 0
+	return v, true
+}
 
-						if isZeroValue {
-							continue
-						}
+// mapValue maps the value of a field. Values of fields with string option are JSON encoded
+// into a string.
+func (f *fieldPlan) mapValue(v reflect.Value) (interface{}, error) {
+	if !f.quoted {
+		return mapValue(v)
+	}
 
-						sliceValuePtr.Set(reflect.Append(sliceValuePtr, reflect.ValueOf(value)))
-					} else {
-						sliceValuePtr.Set(reflect.Append(sliceValuePtr, v))
-					}
-				}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
 
-				if isZeroValue(sliceValuePtr) && omitEmpty {
-					return "", nil, false
-				}
+		v = v.Elem()
+	}
 
-				return fieldName, sliceValuePtr.Interface(), true
-			}
-		}
+	// marshalers ignore the string option
+	if _, ok := findMarshaler(v); ok {
+		return mapValue(v)
+	}
 
-		va := vField
+	b, err := json.Marshal(v.Interface())
 
-		if tField.Type.Kind() == reflect.Ptr {
-			va = vField.Addr()
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		if m, ok := va.Interface().(json.Marshaler); ok {
-			b, err := m.MarshalJSON()
+	return string(b), nil
+}
 
-			if err != nil {
-				return "", nil, false
-			}
+// mapValue maps a value the way encoding/json would encode it. Structs are mapped to PropertyMap,
+// json.Marshaler output to json.RawMessage and encoding.TextMarshaler output to string.
+// time.Time values and maps are kept as they are.
+func mapValue(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
 
-			value := string(b)
-			isZeroValue := len(value) == 0
+	if cachedTypeInfo(v.Type()).isTime {
+		return v.Interface(), nil
+	}
 
-			if isZeroValue && omitEmpty {
-				return "", nil, false
-			}
+	if m, ok := findMarshaler(v); ok {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil, nil
+		}
 
-			return fieldName, value, true
+		switch m := m.(type) {
+		case json.Marshaler:
+			return marshalJSON(m)
+		case encoding.TextMarshaler:
+			return marshalText(m)
 		}
+	}
 
-		if vField.Kind() == reflect.Struct {
-			value := readDataFields(reflect.ValueOf(vField.Interface()))
-			isZeroValue := len(value) == 0
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
 
-			if isZeroValue && omitEmpty {
-				return "", nil, false
-			}
+		return mapValue(v.Elem())
+	case reflect.Struct:
+		return mapStructValue(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
 
-			return fieldName, value, true
+		return mapElements(v)
+	case reflect.Array:
+		return mapElements(v)
+	case reflect.Float32, reflect.Float64:
+		if value := v.Float(); math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("unsupported value: %s", strconv.FormatFloat(value, 'g', -1, 64))
 		}
 	}
 
-	if isZeroValue(vField) && omitEmpty {
-		return "", nil, false
+	if isUnsupportedKind(v.Kind()) {
+		return nil, fmt.Errorf("unsupported type: %s", v.Type())
 	}
 
-	return fieldName, vField.Interface(), true
+	return v.Interface(), nil
 }
 
-func createSlice(sliceType interface{}) reflect.Value {
-	reflection := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(sliceType).Elem()), 0, 0)
-	reflectionValue := reflect.New(reflection.Type())
-	reflectionValue.Elem().Set(reflection)
-	slicePtr := reflect.ValueOf(reflectionValue.Interface())
-	sliceValuePtr := slicePtr.Elem()
+// findMarshaler returns the json.Marshaler or encoding.TextMarshaler of a value. Methods with
+// pointer receiver are used for addressable values only, the same way encoding/json does.
+func findMarshaler(v reflect.Value) (interface{}, bool) {
+	info := cachedTypeInfo(v.Type())
 
-	return sliceValuePtr
-}
+	if info.isAddrMarshaler {
+		if v.CanAddr() {
+			return v.Addr().Interface(), true
+		}
+
+		if info.isMarshaler || info.isTextMarshaler {
+			return v.Interface(), true
+		}
+
+		return nil, false
+	}
+
+	if info.isMarshaler {
+		return v.Interface(), true
+	}
 
-func readEmbeddedField(v reflect.Value) PropertyMap {
-	if isZeroValue(v) {
-		return PropertyMap{}
+	if info.isAddrTextMarshaler && v.CanAddr() {
+		return v.Addr().Interface(), true
 	}
 
-	if !v.CanAddr() {
-		value := reflect.ValueOf(v.Interface())
-		return readDataFields(value)
+	if info.isTextMarshaler {
+		return v.Interface(), true
 	}
 
-	return readDataFields(v.Addr())
+	return nil, false
 }
 
-func isZeroValue(val reflect.Value) bool {
-	if val == reflect.Zero(reflect.TypeOf(val)).Interface() {
-		return true
+// mapElements maps the elements of a slice or array. Elements not encodable as JSON are skipped
+// and the first error is returned.
+func mapElements(v reflect.Value) (interface{}, error) {
+	var sliceType reflect.Type
+
+	switch cachedTypeInfo(v.Type()).elements {
+	case structElements:
+		sliceType = reflect.TypeOf([]PropertyMap{})
+	case rawJSONElements:
+		sliceType = reflect.TypeOf([]json.RawMessage{})
+	case textElements:
+		sliceType = reflect.TypeOf([]string{})
+	case mixedElements:
+		sliceType = reflect.TypeOf([]interface{}{})
+	default:
+		return v.Interface(), nil
 	}
 
-	switch val.Kind() {
-	case reflect.Func:
-		return val.IsNil()
-	case reflect.Struct:
-		isZero := true
+	values := make([]interface{}, 0, v.Len())
+	var firstErr error
 
-		if value, ok := val.Interface().(time.Time); ok {
-			return value.IsZero()
+	for i := 0; i < v.Len(); i++ {
+		value, err := mapValue(v.Index(i))
+
+		if err != nil && firstErr == nil {
+			firstErr = withPath(err, "["+strconv.Itoa(i)+"]")
 		}
 
-		for i := 0; i < val.NumField(); i++ {
-			isZero = isZero && isZeroValue(val.Field(i))
+		if err != nil && value == nil {
+			continue
 		}
 
-		return isZero
-	case reflect.Array, reflect.Slice:
-		isZero := true
+		values = append(values, value)
+	}
 
-		for i := 0; i < val.Len(); i++ {
-			isZero = isZero && isZeroValue(val.Index(i))
-		}
+	return toSlice(sliceType, values), firstErr
+}
+
+// toSlice converts values to a slice of sliceType. If a value is not assignable, []interface{} is returned.
+func toSlice(sliceType reflect.Type, values []interface{}) interface{} {
+	slice := reflect.MakeSlice(sliceType, 0, len(values))
+	elementType := sliceType.Elem()
 
-		return isZero
-	case reflect.Map:
-		isZero := true
+	for _, value := range values {
+		element := reflect.Zero(elementType)
+
+		if value != nil {
+			element = reflect.ValueOf(value)
+		}
 
-		for _, e := range val.MapKeys() {
-			isZero = isZero && isZeroValue(val.MapIndex(e))
+		if !element.Type().AssignableTo(elementType) {
+			return values
 		}
 
-		return isZero
+		slice = reflect.Append(slice, element)
 	}
 
-	if val.CanInterface() {
-		value := val.Interface()
-		zeroValue := reflect.Zero(val.Type()).Interface()
-		return value == zeroValue
+	return slice.Interface()
+}
+
+// marshalJSON returns the compacted output of a json.Marshaler, which is embedded as is
+// when encoding.
+func marshalJSON(m json.Marshaler) (interface{}, error) {
+	b, err := m.MarshalJSON()
+
+	if err != nil {
+		return nil, fmt.Errorf("calling MarshalJSON for type %T: %w", m, err)
+	}
+
+	buffer := new(bytes.Buffer)
+
+	if err := json.Compact(buffer, b); err != nil {
+		return nil, fmt.Errorf("calling MarshalJSON for type %T: %w", m, err)
+	}
+
+	return json.RawMessage(buffer.Bytes()), nil
+}
+
+// marshalText returns the output of an encoding.TextMarshaler, which is encoded as JSON string.
+func marshalText(m encoding.TextMarshaler) (interface{}, error) {
+	b, err := m.MarshalText()
+
+	if err != nil {
+		return nil, fmt.Errorf("calling MarshalText for type %T: %w", m, err)
+	}
+
+	return string(b), nil
+}
+
+// isEmptyValue checks a value for the omitempty option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Ptr:
+		return v.IsZero()
 	}
 
-	return true
+	return false
 }
@@ -4,8 +4,12 @@
 package mapping
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -60,8 +64,9 @@ func TestMapData(t *testing.T) {
 
 	data = MapData(test)
 
-	if count := len(data); count != 4 {
-		t.Errorf("Data amount %d, want %d", count, 4)
+	// arrays with elements and structs are never empty, the same way encoding/json handles omitempty
+	if count := len(data); count != 6 {
+		t.Errorf("Data amount %d, want %d", count, 6)
 	}
 
 	if val, ok := data["a"]; !ok && val != "A" {
@@ -74,8 +79,8 @@ func TestMapData(t *testing.T) {
 
 	data = MapData(&test)
 
-	if count := len(data); count != 4 {
-		t.Errorf("Data amount %d, want %d", count, 4)
+	if count := len(data); count != 6 {
+		t.Errorf("Data amount %d, want %d", count, 6)
 	}
 
 	if val, ok := data["f"]; !ok && val != "F" {
@@ -163,8 +168,8 @@ func TestMapData(t *testing.T) {
 		t.Errorf("expected key %s", "aaaa")
 	}
 
-	if _, ok := val.([]string); !ok {
-		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []string{})
+	if _, ok := val.([]json.RawMessage); !ok {
+		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []json.RawMessage{})
 	}
 
 	val, ok = data["cccc"]
@@ -173,8 +178,8 @@ func TestMapData(t *testing.T) {
 		t.Errorf("expected key %s", "cccc")
 	}
 
-	if _, ok := val.([]string); !ok {
-		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []string{})
+	if _, ok := val.([]json.RawMessage); !ok {
+		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []json.RawMessage{})
 	}
 
 	val, ok = data["string"]
@@ -205,11 +210,11 @@ func TestMapData(t *testing.T) {
 		t.Errorf("expected key %s", "a")
 	}
 
-	if _, ok := val.([]string); !ok {
-		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []string{})
+	if _, ok := val.([]json.RawMessage); !ok {
+		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []json.RawMessage{})
 	}
 
-	if count := len(val.([]string)); count != 0 {
+	if count := len(val.([]json.RawMessage)); count != 0 {
 		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 
@@ -219,11 +224,11 @@ func TestMapData(t *testing.T) {
 		t.Errorf("expected key %s", "b")
 	}
 
-	if _, ok := val.([]string); !ok {
-		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []string{})
+	if _, ok := val.([]json.RawMessage); !ok {
+		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []json.RawMessage{})
 	}
 
-	if count := len(val.([]string)); count != 0 {
+	if count := len(val.([]json.RawMessage)); count != 0 {
 		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 
@@ -231,14 +236,14 @@ func TestMapData(t *testing.T) {
 		A []CustomType4 `json:"a,omitempty"`
 	}
 
-	test10 := Test10{A: []CustomType4{CustomType4("")}}
+	test10 := Test10{A: []CustomType4{}}
 	data = MapData(test10)
 
 	if count := len(data); count != 0 {
 		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 
-	test10 = Test10{A: []CustomType4{CustomType4("test")}}
+	test10 = Test10{A: []CustomType4{CustomType4(`"test"`)}}
 	data = MapData(test10)
 
 	if count := len(data); count != 1 {
@@ -251,11 +256,11 @@ func TestMapData(t *testing.T) {
 		t.Errorf("expected key %s", "a")
 	}
 
-	if _, ok := val.([]string); !ok {
-		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []PropertyMap{})
+	if _, ok := val.([]json.RawMessage); !ok {
+		t.Errorf("type is %s, want %T", reflect.TypeOf(val), []json.RawMessage{})
 	}
 
-	if count := len(val.([]string)); count != 1 {
+	if count := len(val.([]json.RawMessage)); count != 1 {
 		t.Errorf("Data amount %d, want %d", count, 1)
 	}
 
@@ -274,13 +279,21 @@ func TestMapData(t *testing.T) {
 		A []string `json:"a,omitempty"`
 	}
 
-	test12 := Test12{A: []string{""}}
+	test12 := Test12{}
 	data = MapData(test12)
 
 	if count := len(data); count != 0 {
 		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 
+	// a slice with elements is never empty
+	test12.A = []string{""}
+	data = MapData(test12)
+
+	if count := len(data); count != 1 {
+		t.Errorf("Data amount %d, want %d", count, 1)
+	}
+
 	test12.A = append(test12.A, "test value")
 	data = MapData(test12)
 
@@ -342,8 +355,9 @@ func TestMapDataWithPointers(t *testing.T) {
 	test1 = &Test1{Test2: test2, A: test3, B: test4}
 	data = MapData(test1)
 
-	if count := len(data); count != 2 {
-		t.Errorf("Data amount %d, want %d", count, 2)
+	// a pointer is empty, if it is nil
+	if count := len(data); count != 3 {
+		t.Errorf("Data amount %d, want %d", count, 3)
 	}
 
 	if _, ok := data["a"]; !ok {
@@ -388,13 +402,13 @@ func TestMapDataWithPointers(t *testing.T) {
 type CustomType string
 
 func (c *CustomType) MarshalJSON() ([]byte, error) {
-	return []byte("test value"), nil
+	return []byte(`"test value"`), nil
 }
 
 type CustomType2 string
 
 func (c CustomType2) MarshalJSON() ([]byte, error) {
-	return []byte("test value"), nil
+	return []byte(`{ "value": "test value" }`), nil
 }
 
 type CustomType3 string
@@ -449,11 +463,12 @@ func TestMapDataWithMaps(t *testing.T) {
 		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 
+	// a map with entries is never empty
 	test2.A["test"] = CustomType4("")
 	data = MapData(test2)
 
-	if count := len(data); count != 0 {
-		t.Errorf("Data amount %d, want %d", count, 0)
+	if count := len(data); count != 1 {
+		t.Errorf("Data amount %d, want %d", count, 1)
 	}
 
 	test2.A["test"] = CustomType4("value 1")
@@ -476,12 +491,13 @@ func TestMapDataWithFuncs(t *testing.T) {
 		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 
+	// functions are not encodable as JSON
 	testFunc := func() {}
 	test = &Test1{A: testFunc}
 	data = MapData(test)
 
-	if count := len(data); count != 1 {
-		t.Errorf("Data amount %d, want %d", count, 1)
+	if count := len(data); count != 0 {
+		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 }
 
@@ -504,14 +520,21 @@ func TestMapDataWithCustomMarshaler(t *testing.T) {
 		t.Errorf("Expected key %s in data", "a")
 	}
 
-	if v := data["a"]; v != "test value" {
-		t.Errorf("Value is %s, want %s", v, "test value")
+	if v := data["a"]; !reflect.DeepEqual(v, json.RawMessage(`"test value"`)) {
+		t.Errorf("Value is %s, want %s", v, `"test value"`)
 	}
 
 	if _, ok := data["b"]; !ok {
 		t.Errorf("Expected key %s in data", "b")
 	}
 
+	// pointer receiver methods are used for addressable values
+	if v := data["b"]; !reflect.DeepEqual(v, json.RawMessage(`"test value"`)) {
+		t.Errorf("Value is %s, want %s", v, `"test value"`)
+	}
+
+	data = MapData(*test)
+
 	if v := data["b"]; v != CustomType("test2") {
 		t.Errorf("Value is %s, want %s", v, "test2")
 	}
@@ -534,16 +557,16 @@ func TestMapDataWithCustomMarshaler(t *testing.T) {
 		t.Errorf("Expected key %s in data", "a")
 	}
 
-	if v := data["a"]; v != "test value" {
-		t.Errorf("Value is %s, want %s", v, "test value")
+	if v := data["a"]; !reflect.DeepEqual(v, json.RawMessage(`{"value":"test value"}`)) {
+		t.Errorf("Value is %s, want %s", v, `{"value":"test value"}`)
 	}
 
 	if _, ok := data["b"]; !ok {
 		t.Errorf("Expected key %s in data", "b")
 	}
 
-	if v := data["b"]; v != "test value" {
-		t.Errorf("Value is %s, want %s", v, "test value")
+	if v := data["b"]; !reflect.DeepEqual(v, json.RawMessage(`{"value":"test value"}`)) {
+		t.Errorf("Value is %s, want %s", v, `{"value":"test value"}`)
 	}
 
 	type Test3 struct {
@@ -570,3 +593,194 @@ func TestMapDataWithCustomMarshaler(t *testing.T) {
 		t.Errorf("Data amount %d, want %d", count, 0)
 	}
 }
+
+func TestMapDataWithHALTags(t *testing.T) {
+	type Test1 struct {
+		A string   `json:"a"`
+		B string   `json:"b" hal:"link,rel=b"`
+		C []string `json:"c" hal:"embedded,rel=c"`
+	}
+
+	test := Test1{A: "A", B: "B", C: []string{"C"}}
+	data := MapData(test)
+
+	if count := len(data); count != 1 {
+		t.Errorf("Data amount %d, want %d", count, 1)
+	}
+
+	if _, ok := data["a"]; !ok {
+		t.Errorf("Expected key %s in data", "a")
+	}
+}
+
+func TestMapDataWithUnexportedStructs(t *testing.T) {
+	type test2 struct {
+		B string `json:"b"`
+	}
+
+	type Test1 struct {
+		A string `json:"a"`
+		c test2
+	}
+
+	test := Test1{A: "A", c: test2{B: "C"}}
+	data := MapData(test)
+
+	if count := len(data); count != 1 {
+		t.Errorf("Data amount %d, want %d", count, 1)
+	}
+}
+
+func TestMapDataInOrder(t *testing.T) {
+	type Test2 struct {
+		C string `json:"c"`
+		A string `json:"a"`
+	}
+
+	type Test1 struct {
+		Z string `json:"z"`
+		*Test2
+		B string `json:"b" hal:"link"`
+		Y string `json:"y,omitempty"`
+		X string `json:"x"`
+	}
+
+	data, names := MapDataInOrder(&Test1{Z: "Z", Test2: &Test2{C: "C", A: "A"}, B: "B", X: "X"})
+	wanted := []string{"z", "c", "a", "x"}
+
+	if count := len(data); count != len(wanted) {
+		t.Errorf("Data amount %d, want %d", count, len(wanted))
+	}
+
+	if !reflect.DeepEqual(names, wanted) {
+		t.Errorf("Names are %v, want %v", names, wanted)
+	}
+
+	if data, names := MapDataInOrder(nil); len(data) != 0 || len(names) != 0 {
+		t.Errorf("Names are %v, want %v", names, []string{})
+	}
+}
+
+type money struct {
+	amount int
+}
+
+func (m money) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"amount": %d}`, m.amount)), nil
+}
+
+type decimal int
+
+func (d *decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(*d))), nil
+}
+
+type uuid [2]byte
+
+func (u uuid) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%x-%x", u[0], u[1])), nil
+}
+
+type invalidJSON string
+
+func (i invalidJSON) MarshalJSON() ([]byte, error) {
+	return []byte(i), nil
+}
+
+func TestMapDataWithMarshalerOutput(t *testing.T) {
+	type Order struct {
+		Price   money    `json:"price"`
+		Prices  []money  `json:"prices"`
+		Amount  *decimal `json:"amount"`
+		ID      uuid     `json:"id"`
+		IDs     []uuid   `json:"ids"`
+		Value   interface{}
+		Invalid invalidJSON `json:"invalid"`
+	}
+
+	amount := decimal(42)
+	order := Order{
+		Price:   money{5},
+		Prices:  []money{{1}, {2}},
+		Amount:  &amount,
+		ID:      uuid{1, 2},
+		IDs:     []uuid{{3, 4}},
+		Invalid: "{",
+	}
+	data := MapData(order)
+
+	if _, ok := data["invalid"]; ok {
+		t.Errorf("Invalid JSON should be skipped")
+	}
+
+	delete(data, "invalid")
+	value, err := json.Marshal(data)
+
+	if err != nil {
+		t.Fatalf("Marshal returns error: %s", err)
+	}
+
+	wanted := `{"Value":null,"amount":42,"id":"1-2","ids":["3-4"],"price":{"amount":5},"prices":[{"amount":1},{"amount":2}]}`
+
+	if string(value) != wanted {
+		t.Errorf("JSON value == %s, want %s", value, wanted)
+	}
+}
+
+func TestMapDataE(t *testing.T) {
+	type Line struct {
+		Name  string      `json:"name"`
+		Price CustomType3 `json:"price"`
+	}
+
+	type Meta struct {
+		Total *CustomType3 `json:"total"`
+	}
+
+	type Order struct {
+		Meta
+		ID    int     `json:"id"`
+		Lines []Line  `json:"lines"`
+		Ratio float64 `json:"ratio,string"`
+	}
+
+	errorPrice := CustomType3("error")
+	tests := []struct {
+		data interface{}
+		path string
+	}{
+		{Order{Lines: []Line{{}, {}, {}, {Name: "fourth"}}}, "Order.Lines[0].Price"},
+		{&Order{Meta: Meta{Total: &errorPrice}}, "Order.Total"},
+		{Order{Ratio: math.Inf(1)}, "Order.Ratio"},
+		{struct{ A []func() }{A: []func(){nil}}, "A[0]"},
+	}
+
+	for _, test := range tests {
+		data, err := MapDataE(test.data)
+
+		if data != nil {
+			t.Errorf("MapDataE data is %v, want nil", data)
+		}
+
+		var fieldError *FieldError
+
+		if !errors.As(err, &fieldError) {
+			t.Errorf("MapDataE error is %T, want %T", err, fieldError)
+			continue
+		}
+
+		if fieldError.Path != test.path {
+			t.Errorf("Field path is %s, want %s", fieldError.Path, test.path)
+		}
+	}
+
+	data, err := MapDataE(Order{ID: 1})
+
+	if err != nil {
+		t.Errorf("MapDataE returns error: %s", err)
+	}
+
+	if count := len(data); count != 4 {
+		t.Errorf("Data amount %d, want %d", count, 4)
+	}
+}
@@ -0,0 +1,468 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+package mapping
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	marshalerType       = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	unmarshalerType     = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	isZeroerType        = reflect.TypeOf((*isZeroer)(nil)).Elem()
+	propertyMapperType  = reflect.TypeOf((*PropertyMapper)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// isZeroer is implemented by types reporting their zero value for the omitzero option.
+type isZeroer interface {
+	IsZero() bool
+}
+
+// typePlans caches a *typePlan per struct type.
+var typePlans sync.Map
+
+// typeInfos caches a *typeInfo per type.
+var typeInfos sync.Map
+
+// typePlan describes how values of a struct type are mapped.
+// It is created once per type, so struct tags are not parsed on each mapping.
+type typePlan struct {
+	fields []fieldPlan
+	names  []string
+}
+
+// fieldPlan describes how a struct field is mapped. Fields of embedded structs are
+// addressed by an index path.
+type fieldPlan struct {
+	name      string
+	goName    string
+	tagged    bool
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+	isZero    func(reflect.Value) bool
+}
+
+// elementKind defines how the elements of a slice or array are mapped.
+type elementKind int
+
+const (
+	// elements are kept as is
+	asIsElements elementKind = iota
+	// elements are mapped to []PropertyMap
+	structElements
+	// elements are mapped to []json.RawMessage
+	rawJSONElements
+	// elements are mapped to []string
+	textElements
+	// elements are mapped to []interface{}
+	mixedElements
+)
+
+// typeInfo classifies a type, so method sets are not checked on each mapping.
+type typeInfo struct {
+	isTime              bool
+	isMarshaler         bool
+	isAddrMarshaler     bool
+	isTextMarshaler     bool
+	isAddrTextMarshaler bool
+	isMapper            bool
+	isAddrMapper        bool
+	elements            elementKind
+}
+
+// cachedTypePlan returns the typePlan of a struct type.
+func cachedTypePlan(vType reflect.Type) *typePlan {
+	if plan, ok := typePlans.Load(vType); ok {
+		return plan.(*typePlan)
+	}
+
+	plan, _ := typePlans.LoadOrStore(vType, newTypePlan(vType))
+
+	return plan.(*typePlan)
+}
+
+// cachedTypeInfo returns the typeInfo of a type.
+func cachedTypeInfo(vType reflect.Type) *typeInfo {
+	if info, ok := typeInfos.Load(vType); ok {
+		return info.(*typeInfo)
+	}
+
+	info, _ := typeInfos.LoadOrStore(vType, newTypeInfo(vType))
+
+	return info.(*typeInfo)
+}
+
+// embedsMapper reports whether a struct has an embedded field providing ToPropertyMap. Such a
+// method may be promoted, which would map the embedded field only. Structs with embedded mappers
+// are mapped field by field, the same way encoding/json encodes them. A ToPropertyMap method
+// declared by the struct itself is not used in this case.
+func embedsMapper(vType reflect.Type) bool {
+	if vType.Kind() == reflect.Ptr {
+		vType = vType.Elem()
+	}
+
+	if vType.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < vType.NumField(); i++ {
+		field := vType.Field(i)
+
+		if !field.Anonymous {
+			continue
+		}
+
+		if field.Type.Implements(propertyMapperType) || reflect.PtrTo(field.Type).Implements(propertyMapperType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newTypeInfo(vType reflect.Type) *typeInfo {
+	isPointer := vType.Kind() == reflect.Ptr
+	info := &typeInfo{
+		isTime:              vType == timeType,
+		isMarshaler:         vType.Implements(marshalerType),
+		isAddrMarshaler:     !isPointer && reflect.PtrTo(vType).Implements(marshalerType),
+		isTextMarshaler:     vType.Implements(textMarshalerType),
+		isAddrTextMarshaler: !isPointer && reflect.PtrTo(vType).Implements(textMarshalerType),
+		isMapper:            vType.Implements(propertyMapperType) && !embedsMapper(vType),
+		isAddrMapper:        !isPointer && reflect.PtrTo(vType).Implements(propertyMapperType) && !embedsMapper(vType),
+	}
+
+	if vType.Kind() == reflect.Slice || vType.Kind() == reflect.Array {
+		info.elements = readElementKind(vType)
+	}
+
+	return info
+}
+
+// readElementKind classifies the elements of a slice or array type.
+func readElementKind(vType reflect.Type) elementKind {
+	element := vType.Elem()
+	isPointer := element.Kind() == reflect.Ptr
+	target := element
+
+	if isPointer {
+		target = element.Elem()
+	}
+
+	isMarshaler := reflect.PtrTo(target).Implements(marshalerType)
+	isTextMarshaler := reflect.PtrTo(target).Implements(textMarshalerType)
+
+	switch {
+	// byte slices are encoded as base64 string
+	case vType.Kind() == reflect.Slice && element.Kind() == reflect.Uint8 && !isMarshaler && !isTextMarshaler:
+		return asIsElements
+	case isMarshaler:
+		return rawJSONElements
+	case isTextMarshaler && !isPointer:
+		return textElements
+	case isTextMarshaler:
+		return mixedElements
+	case target.Kind() == reflect.Struct:
+		return structElements
+	case isPointer || element.Kind() == reflect.Interface:
+		return mixedElements
+	// elements not encodable as JSON are mapped to report an error
+	case isUnsupportedKind(element.Kind()):
+		return mixedElements
+	}
+
+	return asIsElements
+}
+
+func isUnsupportedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Func, reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return true
+	}
+
+	return false
+}
+
+// newTypePlan selects the fields of a struct type the same way encoding/json does.
+// Fields of embedded structs are promoted. If several fields have the same name,
+// the least nested one is chosen, a tagged one is preferred over an untagged one.
+// Otherwise all of them are ignored. Fields with a hal struct tag are skipped.
+func newTypePlan(vType reflect.Type) *typePlan {
+	current := []fieldPlan{}
+	next := []fieldPlan{{typ: vType}}
+	count := map[reflect.Type]int{}
+	nextCount := map[reflect.Type]int{}
+	visited := map[reflect.Type]bool{}
+	fields := []fieldPlan{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, f := range current {
+			if visited[f.typ] {
+				continue
+			}
+
+			visited[f.typ] = true
+
+			for i := 0; i < f.typ.NumField(); i++ {
+				tField := f.typ.Field(i)
+
+				if _, ok := tField.Tag.Lookup(halTagName); ok {
+					continue
+				}
+
+				if tField.Anonymous {
+					t := tField.Type
+
+					if t.Kind() == reflect.Ptr {
+						t = t.Elem()
+					}
+
+					if !isExported(tField) && t.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !isExported(tField) {
+					continue
+				}
+
+				tag := tField.Tag.Get("json")
+
+				if tag == "-" {
+					continue
+				}
+
+				name, options := parseTag(tag)
+
+				if !isValidTag(name) {
+					name = ""
+				}
+
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+
+				fieldType := tField.Type
+
+				if fieldType.Name() == "" && fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+
+				if name != "" || !tField.Anonymous || fieldType.Kind() != reflect.Struct {
+					field := newFieldPlan(tField, name, options, index)
+					fields = append(fields, field)
+
+					// several embedded structs of the same type at the same level annihilate each other
+					if count[f.typ] > 1 {
+						fields = append(fields, fields[len(fields)-1])
+					}
+
+					continue
+				}
+
+				nextCount[fieldType]++
+
+				if nextCount[fieldType] == 1 {
+					next = append(next, fieldPlan{name: fieldType.Name(), index: index, typ: fieldType})
+				}
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+
+		if fields[i].tagged != fields[j].tagged {
+			return fields[i].tagged
+		}
+
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+
+	dominantFields := []fieldPlan{}
+
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != fields[i].name {
+				break
+			}
+		}
+
+		if field, ok := dominantField(fields[i : i+advance]); ok {
+			dominantFields = append(dominantFields, field)
+		}
+	}
+
+	sort.Slice(dominantFields, func(i, j int) bool {
+		return lessIndex(dominantFields[i].index, dominantFields[j].index)
+	})
+
+	plan := &typePlan{fields: dominantFields, names: make([]string, 0, len(dominantFields))}
+
+	for _, field := range dominantFields {
+		plan.names = append(plan.names, field.name)
+	}
+
+	return plan
+}
+
+func newFieldPlan(tField reflect.StructField, name string, options string, index []int) fieldPlan {
+	field := fieldPlan{
+		name:      name,
+		goName:    tField.Name,
+		tagged:    name != "",
+		index:     index,
+		typ:       tField.Type,
+		omitEmpty: hasOption(options, "omitempty"),
+		omitZero:  hasOption(options, "omitzero"),
+	}
+
+	if field.name == "" {
+		field.name = tField.Name
+	}
+
+	fieldType := tField.Type
+
+	if fieldType.Name() == "" && fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if hasOption(options, "string") {
+		switch fieldType.Kind() {
+		case reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64,
+			reflect.String:
+			field.quoted = true
+		}
+	}
+
+	if field.omitZero {
+		field.isZero = newIsZero(tField.Type)
+	}
+
+	return field
+}
+
+// newIsZero returns a function reporting the zero value of a type for the omitzero option.
+// An IsZero method is preferred to reflect.Value.IsZero.
+func newIsZero(vType reflect.Type) func(reflect.Value) bool {
+	switch {
+	case vType.Kind() == reflect.Interface && vType.Implements(isZeroerType):
+		return func(v reflect.Value) bool {
+			// avoid calling IsZero on a nil interface or a nil pointer
+			return v.IsNil() || (v.Elem().Kind() == reflect.Ptr && v.Elem().IsNil()) || v.Interface().(isZeroer).IsZero()
+		}
+	case vType.Kind() == reflect.Ptr && vType.Implements(isZeroerType):
+		return func(v reflect.Value) bool {
+			return v.IsNil() || v.Interface().(isZeroer).IsZero()
+		}
+	case vType.Implements(isZeroerType):
+		return func(v reflect.Value) bool {
+			return v.Interface().(isZeroer).IsZero()
+		}
+	case reflect.PtrTo(vType).Implements(isZeroerType):
+		return func(v reflect.Value) bool {
+			if !v.CanAddr() {
+				// copy the value to take its address
+				value := reflect.New(v.Type()).Elem()
+				value.Set(v)
+				v = value
+			}
+
+			return v.Addr().Interface().(isZeroer).IsZero()
+		}
+	}
+
+	return func(v reflect.Value) bool {
+		return v.IsZero()
+	}
+}
+
+// dominantField returns the dominant field of fields with the same name, sorted by
+// depth and tag. If there is no dominant field, false is returned.
+func dominantField(fields []fieldPlan) (fieldPlan, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return fieldPlan{}, false
+	}
+
+	return fields[0], true
+}
+
+func lessIndex(a []int, b []int) bool {
+	for i, x := range a {
+		if i >= len(b) {
+			return false
+		}
+
+		if x != b[i] {
+			return x < b[i]
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+func isExported(tField reflect.StructField) bool {
+	return tField.PkgPath == ""
+}
+
+// parseTag splits a json struct tag into name and options.
+func parseTag(tag string) (string, string) {
+	if index := strings.Index(tag, ","); index >= 0 {
+		return tag[:index], tag[index+1:]
+	}
+
+	return tag, ""
+}
+
+// hasOption checks whether comma separated options contain option.
+func hasOption(options string, option string) bool {
+	for _, value := range strings.Split(options, ",") {
+		if value == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValidTag checks whether a json struct tag name is usable as property name.
+func isValidTag(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// backslash and quote chars are reserved
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,230 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+package mapping
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type planAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city,omitempty"`
+}
+
+type planMeta struct {
+	Version int `json:"version"`
+}
+
+type planOrder struct {
+	planMeta
+	ID        int           `json:"id"`
+	Customer  string        `json:"customer"`
+	Created   time.Time     `json:"created"`
+	Address   *planAddress  `json:"address"`
+	Lines     []planAddress `json:"lines"`
+	Tags      []string      `json:"tags,omitempty"`
+	Price     *CustomType   `json:"price"`
+	Value     interface{}   `json:"value"`
+	Comment   string        `json:"-"`
+	Self      string        `hal:"link"`
+	unchecked bool
+}
+
+func createPlanOrder() planOrder {
+	price := CustomType("10")
+
+	return planOrder{
+		planMeta: planMeta{Version: 2},
+		ID:       1,
+		Customer: "Rose Tyler",
+		Created:  time.Date(2005, 3, 26, 19, 0, 0, 0, time.UTC),
+		Address:  &planAddress{Street: "Powell Estate", City: "London"},
+		Lines:    []planAddress{{Street: "Bad Wolf Bay"}, {Street: "Torchwood"}},
+		Tags:     []string{"companion"},
+		Price:    &price,
+		Value:    3,
+	}
+}
+
+// resetTypeCaches removes all cached plans and type infos.
+func resetTypeCaches() {
+	for _, cache := range []*sync.Map{&typePlans, &typeInfos} {
+		cache.Range(func(key, value interface{}) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+}
+
+func TestCachedTypePlan(t *testing.T) {
+	resetTypeCaches()
+	vType := reflect.TypeOf(planOrder{})
+	plan := cachedTypePlan(vType)
+
+	if cached := cachedTypePlan(vType); cached != plan {
+		t.Errorf("Plan should be cached")
+	}
+
+	// hal tagged, ignored and unexported fields are skipped
+	if count := len(plan.fields); count != 9 {
+		t.Errorf("Field plan count %d, want %d", count, 9)
+	}
+
+	wanted := []string{"version", "id", "customer", "created", "address", "lines", "tags", "price", "value"}
+
+	if !reflect.DeepEqual(plan.names, wanted) {
+		t.Errorf("Names are %v, want %v", plan.names, wanted)
+	}
+
+	if index := plan.fields[0].index; !reflect.DeepEqual(index, []int{0, 0}) {
+		t.Errorf("Promoted field index is %v, want %v", index, []int{0, 0})
+	}
+
+	if field := plan.fields[6]; !field.omitEmpty {
+		t.Errorf("Field %s should be omitempty", field.name)
+	}
+}
+
+func TestCachedTypeInfo(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		check func(info *typeInfo) bool
+	}{
+		{time.Time{}, func(info *typeInfo) bool { return info.isTime && info.isMarshaler }},
+		{CustomType(""), func(info *typeInfo) bool { return info.isAddrMarshaler && !info.isMarshaler }},
+		{[]planAddress{}, func(info *typeInfo) bool { return info.elements == structElements }},
+		{[]*planAddress{}, func(info *typeInfo) bool { return info.elements == structElements }},
+		{[]CustomType{}, func(info *typeInfo) bool { return info.elements == rawJSONElements }},
+		{[]string{}, func(info *typeInfo) bool { return info.elements == asIsElements }},
+		{[]byte{}, func(info *typeInfo) bool { return info.elements == asIsElements }},
+		{[]interface{}{}, func(info *typeInfo) bool { return info.elements == mixedElements }},
+	}
+
+	for _, test := range tests {
+		vType := reflect.TypeOf(test.value)
+		info := cachedTypeInfo(vType)
+
+		if !test.check(info) {
+			t.Errorf("Type info of %s is %+v", vType, info)
+		}
+
+		if cached := cachedTypeInfo(vType); cached != info {
+			t.Errorf("Type info of %s should be cached", vType)
+		}
+	}
+}
+
+func TestMapDataConcurrently(t *testing.T) {
+	resetTypeCaches()
+	order := createPlanOrder()
+	wanted := MapData(order)
+	resetTypeCaches()
+
+	var wg sync.WaitGroup
+	results := make([]PropertyMap, 10)
+
+	for i := range results {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			results[i] = MapData(order)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, result := range results {
+		if !reflect.DeepEqual(result, wanted) {
+			t.Errorf("Data is %v, want %v", result, wanted)
+		}
+	}
+}
+
+func BenchmarkMapData(b *testing.B) {
+	order := createPlanOrder()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		MapData(order)
+	}
+}
+
+// BenchmarkMapDataCold maps data without cached plans, the same way
+// struct tags are parsed on each call without a cache.
+func BenchmarkMapDataCold(b *testing.B) {
+	order := createPlanOrder()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		resetTypeCaches()
+		MapData(order)
+	}
+}
+
+func BenchmarkMapDataSlice(b *testing.B) {
+	orders := make([]planOrder, 100)
+
+	for i := range orders {
+		orders[i] = createPlanOrder()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, order := range orders {
+			MapData(order)
+		}
+	}
+}
+
+// legacyOrder has no unexported fields, which MapData of go2hal v0.6.0 can't handle.
+type legacyOrder struct {
+	ID       int           `json:"id"`
+	Customer string        `json:"customer"`
+	Created  time.Time     `json:"created"`
+	Address  *planAddress  `json:"address"`
+	Lines    []planAddress `json:"lines"`
+	Tags     []string      `json:"tags,omitempty"`
+	Price    *CustomType   `json:"price"`
+	Value    interface{}   `json:"value"`
+	Comment  string        `json:"-"`
+}
+
+// BenchmarkMapDataComparison compares MapData with cached and without cached plans to MapData of
+// go2hal v0.6.0, which reads struct fields and tags by reflection on each call.
+func BenchmarkMapDataComparison(b *testing.B) {
+	order := createPlanOrder()
+	value := legacyOrder{ID: order.ID, Customer: order.Customer, Created: order.Created, Address: order.Address,
+		Lines: order.Lines, Tags: order.Tags, Price: order.Price, Value: order.Value}
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			MapData(value)
+		}
+	})
+
+	b.Run("cold", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			resetTypeCaches()
+			MapData(value)
+		}
+	})
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			legacyMapData(value)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+package mapping
+
+import (
+	"reflect"
+)
+
+// PropertyMapper is implemented by types mapping themselves to a PropertyMap without reflection,
+// e.g. by methods generated with cmd/go2hal-gen. MapData prefers ToPropertyMap over mapping
+// the fields of a struct. A ToPropertyMap method is not used for structs with an embedded field
+// providing ToPropertyMap, as it may be promoted from the embedded field.
+//
+// Errors should be *FieldError with a path relative to the type, e.g. ".Lines[0].Price".
+// MapDataE prepends the type name. The returned PropertyMap keeps all properties mapped
+// without error, the same way MapData does. ToPropertyMap must not call MapData for its own type.
+type PropertyMapper interface {
+	ToPropertyMap() (PropertyMap, error)
+}
+
+// MapValue maps a single value the same way MapData maps the value of a field.
+// Pointers are dereferenced, so pass the address of a field to use methods with
+// pointer receiver the same way encoding/json does.
+// It is used by generated ToPropertyMap methods for values needing reflection.
+func MapValue(value interface{}) (interface{}, error) {
+	return mapValue(reflect.ValueOf(value))
+}
+
+// IsEmpty reports whether a value is empty as defined by the omitempty option of encoding/json.
+func IsEmpty(value interface{}) bool {
+	v := reflect.ValueOf(value)
+
+	return !v.IsValid() || isEmptyValue(v)
+}
+
+// IsZero reports whether a value is zero as defined by the omitzero option of encoding/json.
+// An IsZero method of the value is used, if available.
+func IsZero(value interface{}) bool {
+	v := reflect.ValueOf(value)
+
+	return !v.IsValid() || newIsZero(v.Type())(v)
+}
+
+// WithFieldPath prepends a path segment, e.g. ".Lines", to the path of a *FieldError.
+// Other errors are wrapped into a *FieldError with the segment as path.
+func WithFieldPath(err error, segment string) error {
+	return withPath(err, segment)
+}
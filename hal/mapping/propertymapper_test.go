@@ -0,0 +1,174 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+package mapping
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mappedLine struct {
+	Product string
+	Fail    bool
+}
+
+func (l mappedLine) ToPropertyMap() (PropertyMap, error) {
+	if l.Fail {
+		return PropertyMap{"product": l.Product}, &FieldError{Path: ".Price", Err: errors.New("no price")}
+	}
+
+	return PropertyMap{"product": l.Product, "mapped": true}, nil
+}
+
+type mappedOrder struct {
+	ID    int          `json:"id"`
+	Lines []mappedLine `json:"lines"`
+}
+
+type addrMappedLine struct {
+	Product string `json:"product"`
+}
+
+func (l *addrMappedLine) ToPropertyMap() (PropertyMap, error) {
+	return PropertyMap{"product": l.Product, "mapped": true}, nil
+}
+
+type addrMappedOrder struct {
+	Line addrMappedLine `json:"line"`
+}
+
+func TestMapDataWithPropertyMapper(t *testing.T) {
+	result := MapData(mappedLine{Product: "TARDIS"})
+	wanted := PropertyMap{"product": "TARDIS", "mapped": true}
+
+	if !reflect.DeepEqual(result, wanted) {
+		t.Errorf("PropertyMap is %v, want %v", result, wanted)
+	}
+
+	result = MapData(&mappedOrder{ID: 1, Lines: []mappedLine{{Product: "TARDIS"}}})
+	wanted = PropertyMap{"id": 1, "lines": []PropertyMap{{"product": "TARDIS", "mapped": true}}}
+
+	if !reflect.DeepEqual(result, wanted) {
+		t.Errorf("PropertyMap is %v, want %v", result, wanted)
+	}
+
+	// methods with pointer receiver are used for addressable values only
+	result = MapData(&addrMappedOrder{Line: addrMappedLine{Product: "TARDIS"}})
+	wanted = PropertyMap{"line": PropertyMap{"product": "TARDIS", "mapped": true}}
+
+	if !reflect.DeepEqual(result, wanted) {
+		t.Errorf("PropertyMap is %v, want %v", result, wanted)
+	}
+
+	result = MapData(addrMappedLine{Product: "TARDIS"})
+	wanted = PropertyMap{"product": "TARDIS"}
+
+	if !reflect.DeepEqual(result, wanted) {
+		t.Errorf("PropertyMap is %v, want %v", result, wanted)
+	}
+}
+
+type extendedLine struct {
+	mappedLine
+	Extra string `json:"extra"`
+}
+
+type extendedAddrLine struct {
+	*addrMappedLine
+	Extra string `json:"extra"`
+}
+
+func TestMapDataWithEmbeddedPropertyMapper(t *testing.T) {
+	// promoted ToPropertyMap methods are not used, embedded fields are mapped the same way encoding/json does
+	tests := []struct {
+		data   interface{}
+		wanted PropertyMap
+	}{
+		{extendedLine{mappedLine{Product: "TARDIS"}, "blue"}, PropertyMap{"Product": "TARDIS", "Fail": false, "extra": "blue"}},
+		{&extendedLine{mappedLine{Product: "TARDIS"}, "blue"}, PropertyMap{"Product": "TARDIS", "Fail": false, "extra": "blue"}},
+		{extendedAddrLine{&addrMappedLine{Product: "TARDIS"}, "blue"}, PropertyMap{"product": "TARDIS", "extra": "blue"}},
+	}
+
+	for _, test := range tests {
+		if result := MapData(test.data); !reflect.DeepEqual(result, test.wanted) {
+			t.Errorf("PropertyMap of %T is %v, want %v", test.data, result, test.wanted)
+		}
+	}
+}
+
+func TestMapDataEWithPropertyMapper(t *testing.T) {
+	order := mappedOrder{ID: 1, Lines: []mappedLine{{Product: "TARDIS"}, {Product: "Sonic screwdriver", Fail: true}}}
+	_, err := MapDataE(order)
+	fieldError := &FieldError{}
+
+	if !errors.As(err, &fieldError) {
+		t.Fatalf("Error is %v, want a *FieldError", err)
+	}
+
+	if fieldError.Path != "mappedOrder.Lines[1].Price" {
+		t.Errorf("Path is %s, want %s", fieldError.Path, "mappedOrder.Lines[1].Price")
+	}
+
+	// partially mapped values are kept
+	lines := MapData(order)["lines"].([]PropertyMap)
+
+	if len(lines) != 2 || lines[1]["product"] != "Sonic screwdriver" {
+		t.Errorf("Lines are %v, want 2 lines", lines)
+	}
+}
+
+func TestMapValue(t *testing.T) {
+	line := addrMappedLine{Product: "TARDIS"}
+	value, err := MapValue(&line)
+
+	if err != nil {
+		t.Fatalf("MapValue returns error: %s", err)
+	}
+
+	wanted := PropertyMap{"product": "TARDIS", "mapped": true}
+
+	if !reflect.DeepEqual(value, wanted) {
+		t.Errorf("Value is %v, want %v", value, wanted)
+	}
+
+	if _, err := MapValue(func() {}); err == nil {
+		t.Errorf("MapValue should return an error for unsupported types")
+	}
+}
+
+func TestIsEmptyAndIsZero(t *testing.T) {
+	tests := []struct {
+		value  interface{}
+		empty  bool
+		isZero bool
+	}{
+		{nil, true, true},
+		{"", true, true},
+		{[]string{}, true, false},
+		{map[string]int{}, true, false},
+		{0.0, true, true},
+		{time.Time{}, false, true},
+		{mappedLine{}, false, true},
+		{[1]int{}, false, true},
+		{"TARDIS", false, false},
+	}
+
+	for _, test := range tests {
+		if empty := IsEmpty(test.value); empty != test.empty {
+			t.Errorf("IsEmpty(%#v) is %t, want %t", test.value, empty, test.empty)
+		}
+
+		if isZero := IsZero(test.value); isZero != test.isZero {
+			t.Errorf("IsZero(%#v) is %t, want %t", test.value, isZero, test.isZero)
+		}
+	}
+
+	var path error = &FieldError{Path: "[0]", Err: errors.New("failed")}
+
+	if err := WithFieldPath(path, ".Lines"); err.Error() != ".Lines[0]: failed" {
+		t.Errorf("Error is %s, want %s", err, ".Lines[0]: failed")
+	}
+}
@@ -0,0 +1,187 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+package mapping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Unmarshal stores the values of a PropertyMap in the struct v points to. It is the reverse
+// of MapData and uses the same rules for selecting and naming fields. A property is assigned
+// to the field with exactly the same name, otherwise to a field with a case-insensitive
+// matching name. Unknown properties are ignored.
+//
+// Values are assigned the way encoding/json decodes them, including json.Unmarshaler,
+// encoding.TextUnmarshaler and time.Time values. If a value can't be assigned, a *FieldError
+// with the path of the field is returned.
+func Unmarshal(propertyMap PropertyMap, v interface{}) error {
+	value := reflect.ValueOf(v)
+
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("Unmarshal requires a non-nil pointer")
+	}
+
+	value = indirect(value.Elem())
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("Unmarshal requires a pointer to a struct, not %s", reflect.TypeOf(v))
+	}
+
+	if err := unmarshalStruct(propertyMap, value); err != nil {
+		err = withPath(err, value.Type().Name())
+
+		// anonymous structs have no type name
+		if fieldError := err.(*FieldError); strings.HasPrefix(fieldError.Path, ".") {
+			fieldError.Path = fieldError.Path[1:]
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// indirect allocates nil pointers and returns the value finally pointed to.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func unmarshalStruct(propertyMap PropertyMap, v reflect.Value) error {
+	plan := cachedTypePlan(v.Type())
+	names := make([]string, 0, len(propertyMap))
+
+	for name := range propertyMap {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := findField(plan, name)
+
+		if field == nil {
+			continue
+		}
+
+		vField, err := allocFieldByIndex(v, field.index)
+
+		if err == nil {
+			err = field.unmarshalValue(propertyMap[name], vField)
+		}
+
+		if err != nil {
+			return withPath(err, "."+field.goName)
+		}
+	}
+
+	return nil
+}
+
+// findField returns the field with exactly the same name, otherwise a field with
+// a case-insensitive matching name.
+func findField(plan *typePlan, name string) *fieldPlan {
+	var folded *fieldPlan
+
+	for i := range plan.fields {
+		field := &plan.fields[i]
+
+		if field.name == name {
+			return field
+		}
+
+		if folded == nil && strings.EqualFold(field.name, name) {
+			folded = field
+		}
+	}
+
+	return folded
+}
+
+// allocFieldByIndex returns the field of an index path. Nil embedded struct pointers are allocated.
+func allocFieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct: %s", v.Type().Elem())
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v, nil
+}
+
+// unmarshalValue assigns a value to a field. Values of fields with string option
+// contain JSON encoded into a string.
+func (f *fieldPlan) unmarshalValue(value interface{}, v reflect.Value) error {
+	if s, ok := value.(string); ok && f.quoted {
+		return json.Unmarshal([]byte(s), v.Addr().Interface())
+	}
+
+	return unmarshalValue(value, v)
+}
+
+// unmarshalValue assigns a value to v. A PropertyMap is assigned to a struct field by field,
+// all other values by a JSON round trip.
+func unmarshalValue(value interface{}, v reflect.Value) error {
+	if propertyMap, ok := toPropertyMap(value); ok && isMappedStruct(v.Type()) {
+		return unmarshalStruct(propertyMap, indirect(v))
+	}
+
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v.Addr().Interface())
+}
+
+// toPropertyMap converts values decoded as JSON object to a PropertyMap.
+func toPropertyMap(value interface{}) (PropertyMap, bool) {
+	switch value := value.(type) {
+	case PropertyMap:
+		return value, true
+	case map[string]interface{}:
+		return PropertyMap(value), true
+	}
+
+	return nil, false
+}
+
+// isMappedStruct checks whether values of a type are mapped field by field. Types decoding
+// themselves are assigned by JSON round trip.
+func isMappedStruct(vType reflect.Type) bool {
+	for vType.Kind() == reflect.Ptr {
+		vType = vType.Elem()
+	}
+
+	if vType.Kind() != reflect.Struct {
+		return false
+	}
+
+	pointerType := reflect.PtrTo(vType)
+
+	return !pointerType.Implements(unmarshalerType) && !pointerType.Implements(textUnmarshalerType)
+}
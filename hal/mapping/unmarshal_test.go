@@ -0,0 +1,214 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+package mapping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type amount struct {
+	cents int
+}
+
+func (a amount) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"cents":%d}`, a.cents)), nil
+}
+
+func (a *amount) UnmarshalJSON(b []byte) error {
+	var value struct {
+		Cents int `json:"cents"`
+	}
+
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+
+	a.cents = value.Cents
+
+	return nil
+}
+
+type code string
+
+func (c code) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(c))), nil
+}
+
+func (c *code) UnmarshalText(b []byte) error {
+	*c = code(strings.ToLower(string(b)))
+
+	return nil
+}
+
+type roundTripAudit struct {
+	Created time.Time  `json:"created"`
+	Updated *time.Time `json:"updated,omitempty"`
+}
+
+type roundTripLine struct {
+	Product  string  `json:"product"`
+	Quantity int     `json:"quantity"`
+	Price    amount  `json:"price"`
+	Discount *amount `json:"discount,omitempty"`
+}
+
+type roundTripOrder struct {
+	roundTripAudit
+	*RoundTripMeta
+	ID        int               `json:"id,string"`
+	Customer  *string           `json:"customer"`
+	Code      code              `json:"code"`
+	Lines     []roundTripLine   `json:"lines"`
+	Pointers  []*roundTripLine  `json:"pointers"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Tags      []string          `json:"tags"`
+	Rating    float64           `json:"rating,omitzero"`
+	Express   bool              `json:"express,string"`
+	Primary   *roundTripLine    `json:"primary"`
+	Untagged  string
+	Ignored   string      `json:"-"`
+	Link      string      `hal:"link"`
+	Any       interface{} `json:"any"`
+	unchecked bool
+}
+
+type RoundTripMeta struct {
+	Version int `json:"version"`
+}
+
+func createRoundTripOrder() roundTripOrder {
+	customer := "Rose Tyler"
+	created := time.Date(2005, 3, 26, 19, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	return roundTripOrder{
+		roundTripAudit: roundTripAudit{Created: created, Updated: &updated},
+		RoundTripMeta:  &RoundTripMeta{Version: 3},
+		ID:             42,
+		Customer:       &customer,
+		Code:           "tardis",
+		Lines: []roundTripLine{
+			{Product: "Sonic Screwdriver", Quantity: 1, Price: amount{1999}},
+			{Product: "Jelly Babies", Quantity: 12, Price: amount{99}, Discount: &amount{10}},
+		},
+		Pointers: []*roundTripLine{{Product: "Key", Quantity: 1}, nil},
+		Labels:   map[string]string{"priority": "high"},
+		Tags:     []string{"companion", "bad wolf"},
+		Rating:   4.5,
+		Express:  true,
+		Untagged: "untagged",
+		Any:      "any",
+	}
+}
+
+func TestUnmarshalRoundTrip(t *testing.T) {
+	orders := []roundTripOrder{
+		createRoundTripOrder(),
+		{},
+		{Lines: []roundTripLine{}, Tags: []string{}},
+	}
+
+	for _, order := range orders {
+		data, err := MapDataE(order)
+
+		if err != nil {
+			t.Fatalf("MapDataE returns error: %s", err)
+		}
+
+		result := roundTripOrder{}
+
+		if err := Unmarshal(data, &result); err != nil {
+			t.Fatalf("Unmarshal returns error: %s", err)
+		}
+
+		if !reflect.DeepEqual(result, order) {
+			t.Errorf("Unmarshal result is %+v, want %+v", result, order)
+		}
+	}
+}
+
+func TestUnmarshalDecodedJSON(t *testing.T) {
+	order := createRoundTripOrder()
+	b, _ := json.Marshal(MapData(order))
+	decoder := json.NewDecoder(strings.NewReader(string(b)))
+	decoder.UseNumber()
+	data := PropertyMap{}
+
+	if err := decoder.Decode(&data); err != nil {
+		t.Fatalf("Decode returns error: %s", err)
+	}
+
+	result := roundTripOrder{}
+
+	if err := Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal returns error: %s", err)
+	}
+
+	if !reflect.DeepEqual(result, order) {
+		t.Errorf("Unmarshal result is %+v, want %+v", result, order)
+	}
+}
+
+func TestUnmarshalWithCaseInsensitiveNames(t *testing.T) {
+	type Test1 struct {
+		Name  string `json:"name"`
+		Alias string `json:"NAME"`
+		Count int    `json:"count"`
+	}
+
+	result := Test1{}
+	err := Unmarshal(PropertyMap{"name": "exact", "NAME": "alias", "COUNT": 3, "unknown": true}, &result)
+
+	if err != nil {
+		t.Fatalf("Unmarshal returns error: %s", err)
+	}
+
+	wanted := Test1{Name: "exact", Alias: "alias", Count: 3}
+
+	if result != wanted {
+		t.Errorf("Unmarshal result is %+v, want %+v", result, wanted)
+	}
+}
+
+func TestUnmarshalWithInvalidValues(t *testing.T) {
+	result := roundTripOrder{}
+	invalidTargets := []interface{}{nil, result, new(int), (*roundTripOrder)(nil)}
+
+	for _, target := range invalidTargets {
+		if err := Unmarshal(PropertyMap{}, target); err == nil {
+			t.Errorf("Unmarshal should return an error for %T", target)
+		}
+	}
+
+	tests := []struct {
+		data PropertyMap
+		path string
+	}{
+		{PropertyMap{"id": "forty-two"}, "roundTripOrder.ID"},
+		{PropertyMap{"lines": []PropertyMap{{"quantity": "one"}}}, "roundTripOrder.Lines"},
+		{PropertyMap{"primary": PropertyMap{"quantity": strconv.Quote("one")}}, "roundTripOrder.Primary.Quantity"},
+		{PropertyMap{"version": "3"}, "roundTripOrder.Version"},
+	}
+
+	for _, test := range tests {
+		err := Unmarshal(test.data, &result)
+		var fieldError *FieldError
+
+		if !errors.As(err, &fieldError) {
+			t.Errorf("Unmarshal error is %T, want %T", err, fieldError)
+			continue
+		}
+
+		if fieldError.Path != test.path {
+			t.Errorf("Field path is %s, want %s", fieldError.Path, test.path)
+		}
+	}
+}
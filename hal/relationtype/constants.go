@@ -4,10 +4,41 @@
 
 package relationtype
 
+import (
+	"strings"
+)
+
+//go:generate go run gen.go
+
 // CURIES provides a reserved name for CURIEs link relation type in HAL documents.
 const CURIES string = "curies"
 
-// Self provides a reserved name for self link relation type in HAL documents.
-// 'self' is an IANA registered link relation type.
+// Registration describes an IANA registered link relation type.
 // See http://www.iana.org/assignments/link-relations/link-relations.xhtml.
-const Self string = "self"
+type Registration struct {
+	Name        string
+	Description string
+	Reference   string
+}
+
+// Lookup returns the registration of a link relation type. Names are compared case-insensitively.
+// False is returned for link relation types not registered at IANA.
+func Lookup(name string) (Registration, bool) {
+	registration, ok := registrations[strings.ToLower(name)]
+
+	return registration, ok
+}
+
+// IsRegistered checks whether a link relation type is registered at IANA.
+func IsRegistered(name string) bool {
+	_, ok := Lookup(name)
+
+	return ok
+}
+
+// IsExtension checks whether a link relation type is an extension relation type.
+// Extension relation types are URIs, e.g. http://example.com/rels/doctors, or CURIEs,
+// e.g. doc:doctors. Registered relation types never contain a colon.
+func IsExtension(name string) bool {
+	return strings.Index(name, ":") > 0
+}
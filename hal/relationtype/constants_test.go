@@ -0,0 +1,63 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package relationtype
+
+import (
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	registration, ok := Lookup("Edit-Form")
+
+	if !ok {
+		t.Fatalf("Lookup should find %s", EditForm)
+	}
+
+	if registration.Name != EditForm {
+		t.Errorf("Name is %s, want %s", registration.Name, EditForm)
+	}
+
+	if registration.Reference != "[RFC6861]" {
+		t.Errorf("Reference is %s, want %s", registration.Reference, "[RFC6861]")
+	}
+
+	if registration.Description == "" {
+		t.Errorf("Description should not be empty")
+	}
+
+	if _, ok := Lookup(CURIES); ok {
+		t.Errorf("Lookup should not find %s", CURIES)
+	}
+}
+
+func TestIsRegistered(t *testing.T) {
+	registered := []string{Self, Next, Collection, "intervalafter", "P3Pv1", Openid2LocalID}
+
+	for _, name := range registered {
+		if !IsRegistered(name) {
+			t.Errorf("%s should be registered", name)
+		}
+
+		if IsExtension(name) {
+			t.Errorf("%s should be no extension", name)
+		}
+	}
+
+	extensions := []string{"http://example.com/rels/doctors", "doc:doctors", "urn:example:doctors"}
+
+	for _, name := range extensions {
+		if IsRegistered(name) {
+			t.Errorf("%s should not be registered", name)
+		}
+
+		if !IsExtension(name) {
+			t.Errorf("%s should be an extension", name)
+		}
+	}
+
+	if IsRegistered("doctors") || IsExtension("doctors") {
+		t.Errorf("doctors should neither be registered nor an extension")
+	}
+}
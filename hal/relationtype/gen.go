@@ -0,0 +1,107 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+//go:build ignore
+
+// gen.go creates iana.go from link-relations.csv, a copy of the IANA link relations registry.
+// To refresh the registry, download https://www.iana.org/assignments/link-relations/link-relations-1.csv
+// to link-relations.csv and run go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// initialisms are name segments written in upper case.
+var initialisms = map[string]bool{"acl": true, "api": true, "dns": true, "ice": true, "id": true, "sip": true}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	file, err := os.Open("link-relations.csv")
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "// Code generated by gen.go from link-relations.csv; DO NOT EDIT.\n\npackage relationtype\n\n")
+	names := map[string]string{}
+
+	for _, record := range records[1:] {
+		name, description, reference := record[0], normalize(record[1]), normalize(record[2])
+		identifier := toIdentifier(name)
+
+		if other, ok := names[identifier]; ok {
+			log.Fatalf("%s and %s have the same identifier %s", other, name, identifier)
+		}
+
+		names[identifier] = name
+		fmt.Fprintf(buffer, "// %s is the IANA registered %q link relation type.\n", identifier, name)
+		fmt.Fprintf(buffer, "// %s\n// See %s.\n", description, reference)
+		fmt.Fprintf(buffer, "const %s string = %q\n\n", identifier, name)
+	}
+
+	fmt.Fprintf(buffer, "// registrations contains all IANA registered link relation types by lower case name.\n")
+	fmt.Fprintf(buffer, "var registrations = map[string]Registration{\n")
+
+	for _, record := range records[1:] {
+		name, description, reference := record[0], normalize(record[1]), normalize(record[2])
+		fmt.Fprintf(buffer, "%q: {Name: %s, Description: %q, Reference: %q},\n", strings.ToLower(name), toIdentifier(name), description, reference)
+	}
+
+	fmt.Fprintf(buffer, "}\n")
+	source, err := format.Source(buffer.Bytes())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("iana.go", source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// normalize removes line breaks and repeated spaces.
+func normalize(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// toIdentifier converts a relation name into an exported Go identifier, e.g. edit-form into EditForm.
+func toIdentifier(name string) string {
+	segments := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '.' || r == '_'
+	})
+	identifier := ""
+
+	for _, segment := range segments {
+		if initialisms[strings.ToLower(segment)] {
+			identifier += strings.ToUpper(segment)
+
+			continue
+		}
+
+		runes := []rune(segment)
+		runes[0] = unicode.ToUpper(runes[0])
+		identifier += string(runes)
+	}
+
+	return identifier
+}
@@ -0,0 +1,757 @@
+// Code generated by gen.go from link-relations.csv; DO NOT EDIT.
+
+package relationtype
+
+// About is the IANA registered "about" link relation type.
+// Refers to a resource that is the subject of the link's context.
+// See [RFC6903], section 2.
+const About string = "about"
+
+// ACL is the IANA registered "acl" link relation type.
+// Asserts that the link target provides an access control description for the link context.
+// See [https://solidproject.org/TR/protocol#link-relation-acl].
+const ACL string = "acl"
+
+// Alternate is the IANA registered "alternate" link relation type.
+// Refers to a substitute for this context
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-alternate].
+const Alternate string = "alternate"
+
+// Amphtml is the IANA registered "amphtml" link relation type.
+// Used to reference alternative content that uses the AMP profile of the HTML format.
+// See [https://amp.dev/documentation/guides-and-tutorials/learn/spec/amphtml/].
+const Amphtml string = "amphtml"
+
+// APICatalog is the IANA registered "api-catalog" link relation type.
+// Refers to a list of APIs available from the publisher of the link context.
+// See [RFC9727].
+const APICatalog string = "api-catalog"
+
+// Appendix is the IANA registered "appendix" link relation type.
+// Refers to an appendix.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Appendix string = "appendix"
+
+// AppleTouchIcon is the IANA registered "apple-touch-icon" link relation type.
+// Refers to an icon for the context. Synonym for icon.
+// See [https://developer.apple.com/library/archive/documentation/AppleApplications/Reference/SafariWebContent/ConfiguringWebApplications/ConfiguringWebApplications.html].
+const AppleTouchIcon string = "apple-touch-icon"
+
+// AppleTouchStartupImage is the IANA registered "apple-touch-startup-image" link relation type.
+// Refers to a launch screen for the context.
+// See [https://developer.apple.com/library/archive/documentation/AppleApplications/Reference/SafariWebContent/ConfiguringWebApplications/ConfiguringWebApplications.html].
+const AppleTouchStartupImage string = "apple-touch-startup-image"
+
+// Archives is the IANA registered "archives" link relation type.
+// Refers to a collection of records, documents, or other materials of historical interest.
+// See [http://www.w3.org/TR/2011/WD-html5-20110113/links.html#rel-archives].
+const Archives string = "archives"
+
+// Author is the IANA registered "author" link relation type.
+// Refers to the context's author.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-author].
+const Author string = "author"
+
+// BlockedBy is the IANA registered "blocked-by" link relation type.
+// Identifies the entity that blocks access to a resource following receipt of a legal demand.
+// See [RFC7725].
+const BlockedBy string = "blocked-by"
+
+// Bookmark is the IANA registered "bookmark" link relation type.
+// Gives a permanent link to use for bookmarking purposes.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-bookmark].
+const Bookmark string = "bookmark"
+
+// Canonical is the IANA registered "canonical" link relation type.
+// Designates the preferred version of a resource (the IRI and its contents).
+// See [RFC6596].
+const Canonical string = "canonical"
+
+// Chapter is the IANA registered "chapter" link relation type.
+// Refers to a chapter in a collection of resources.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Chapter string = "chapter"
+
+// CiteAs is the IANA registered "cite-as" link relation type.
+// Indicates that the link target is preferred over the link context for the purpose of permanent citation.
+// See [RFC8574].
+const CiteAs string = "cite-as"
+
+// Collection is the IANA registered "collection" link relation type.
+// The target IRI points to a resource which represents the collection resource for the context IRI.
+// See [RFC6573].
+const Collection string = "collection"
+
+// Contents is the IANA registered "contents" link relation type.
+// Refers to a table of contents.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Contents string = "contents"
+
+// ConvertedFrom is the IANA registered "convertedFrom" link relation type.
+// The document linked to was later converted to the document that contains this link relation. For example, an RFC can have a link to the Internet-Draft that became the RFC; in that case, the link relation would be "convertedFrom".
+// See [RFC7991].
+const ConvertedFrom string = "convertedFrom"
+
+// Copyright is the IANA registered "copyright" link relation type.
+// Refers to a copyright statement that applies to the link's context.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Copyright string = "copyright"
+
+// CreateForm is the IANA registered "create-form" link relation type.
+// The target IRI points to a resource where a submission form can be obtained.
+// See [RFC6861].
+const CreateForm string = "create-form"
+
+// Current is the IANA registered "current" link relation type.
+// Refers to a resource containing the most recent item(s) in a collection of resources.
+// See [RFC5005].
+const Current string = "current"
+
+// Deprecation is the IANA registered "deprecation" link relation type.
+// Refers to a resource that provides information about the context's deprecation.
+// See [RFC9745].
+const Deprecation string = "deprecation"
+
+// Describedby is the IANA registered "describedby" link relation type.
+// Refers to a resource providing information about the link's context.
+// See [http://www.w3.org/TR/powder-dr/#assoc-linking].
+const Describedby string = "describedby"
+
+// Describes is the IANA registered "describes" link relation type.
+// The relationship A 'describes' B asserts that resource A provides a description of resource B. There are no constraints on the format or representation of either A or B, neither are there any further constraints on either resource.
+// See [RFC6892].
+const Describes string = "describes"
+
+// Disclosure is the IANA registered "disclosure" link relation type.
+// Refers to a list of patent disclosures made with respect to material for which 'disclosure' relation is specified.
+// See [RFC6579].
+const Disclosure string = "disclosure"
+
+// DNSPrefetch is the IANA registered "dns-prefetch" link relation type.
+// Used to indicate an origin that will be used to fetch required resources for the link context, and that the user agent ought to resolve as early as possible.
+// See [https://www.w3.org/TR/resource-hints/].
+const DNSPrefetch string = "dns-prefetch"
+
+// Duplicate is the IANA registered "duplicate" link relation type.
+// Refers to a resource whose available representations are byte-for-byte identical with the corresponding representations of the context IRI.
+// See [RFC6249].
+const Duplicate string = "duplicate"
+
+// Edit is the IANA registered "edit" link relation type.
+// Refers to a resource that can be used to edit the link's context.
+// See [RFC5023].
+const Edit string = "edit"
+
+// EditForm is the IANA registered "edit-form" link relation type.
+// The target IRI points to a resource where a submission form for editing associated resource can be obtained.
+// See [RFC6861].
+const EditForm string = "edit-form"
+
+// EditMedia is the IANA registered "edit-media" link relation type.
+// Refers to a resource that can be used to edit media associated with the link's context.
+// See [RFC5023].
+const EditMedia string = "edit-media"
+
+// Enclosure is the IANA registered "enclosure" link relation type.
+// Identifies a related resource that is potentially large and might require special handling.
+// See [RFC4287].
+const Enclosure string = "enclosure"
+
+// External is the IANA registered "external" link relation type.
+// Refers to a resource that is not part of the same site as the current context.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-external].
+const External string = "external"
+
+// First is the IANA registered "first" link relation type.
+// An IRI that refers to the furthest preceding resource in a series of resources.
+// See [RFC8288].
+const First string = "first"
+
+// Geofeed is the IANA registered "geofeed" link relation type.
+// Refers to a geofeed file as described in RFC 8805.
+// See [RFC9632].
+const Geofeed string = "geofeed"
+
+// Glossary is the IANA registered "glossary" link relation type.
+// Refers to a glossary of terms.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Glossary string = "glossary"
+
+// Help is the IANA registered "help" link relation type.
+// Refers to context-sensitive help.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-help].
+const Help string = "help"
+
+// Hosts is the IANA registered "hosts" link relation type.
+// Refers to a resource hosted by the server indicated by the link context.
+// See [RFC6690].
+const Hosts string = "hosts"
+
+// Hub is the IANA registered "hub" link relation type.
+// Refers to a hub that enables registration for notification of updates to the context.
+// See [https://www.w3.org/TR/websub/].
+const Hub string = "hub"
+
+// ICEServer is the IANA registered "ice-server" link relation type.
+// Conveys the URI of an ICE server in the Link header of a WHIP or WHEP response.
+// See [RFC9725].
+const ICEServer string = "ice-server"
+
+// Icon is the IANA registered "icon" link relation type.
+// Refers to an icon representing the link's context.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-icon].
+const Icon string = "icon"
+
+// Index is the IANA registered "index" link relation type.
+// Refers to an index.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Index string = "index"
+
+// IntervalAfter is the IANA registered "intervalAfter" link relation type.
+// refers to a resource associated with a time interval that ends before the beginning of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalAfter].
+const IntervalAfter string = "intervalAfter"
+
+// IntervalBefore is the IANA registered "intervalBefore" link relation type.
+// refers to a resource associated with a time interval that begins after the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalBefore].
+const IntervalBefore string = "intervalBefore"
+
+// IntervalContains is the IANA registered "intervalContains" link relation type.
+// refers to a resource associated with a time interval that begins after the beginning of the time interval associated with the context resource, and ends before the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalContains].
+const IntervalContains string = "intervalContains"
+
+// IntervalDisjoint is the IANA registered "intervalDisjoint" link relation type.
+// refers to a resource associated with a time interval that begins after the end of the time interval associated with the context resource, or ends before the beginning of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalDisjoint].
+const IntervalDisjoint string = "intervalDisjoint"
+
+// IntervalDuring is the IANA registered "intervalDuring" link relation type.
+// refers to a resource associated with a time interval that begins before the beginning of the time interval associated with the context resource, and ends after the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalDuring].
+const IntervalDuring string = "intervalDuring"
+
+// IntervalEquals is the IANA registered "intervalEquals" link relation type.
+// refers to a resource associated with a time interval whose beginning coincides with the beginning of the time interval associated with the context resource, and whose end coincides with the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalEquals].
+const IntervalEquals string = "intervalEquals"
+
+// IntervalFinishedBy is the IANA registered "intervalFinishedBy" link relation type.
+// refers to a resource associated with a time interval that begins after the beginning of the time interval associated with the context resource, and whose end coincides with the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalFinishedBy].
+const IntervalFinishedBy string = "intervalFinishedBy"
+
+// IntervalFinishes is the IANA registered "intervalFinishes" link relation type.
+// refers to a resource associated with a time interval that begins before the beginning of the time interval associated with the context resource, and whose end coincides with the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalFinishes].
+const IntervalFinishes string = "intervalFinishes"
+
+// IntervalIn is the IANA registered "intervalIn" link relation type.
+// refers to a resource associated with a time interval that begins before or is coincident with the beginning of the time interval associated with the context resource, and ends after or is coincident with the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalIn].
+const IntervalIn string = "intervalIn"
+
+// IntervalMeets is the IANA registered "intervalMeets" link relation type.
+// refers to a resource associated with a time interval whose beginning coincides with the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalMeets].
+const IntervalMeets string = "intervalMeets"
+
+// IntervalMetBy is the IANA registered "intervalMetBy" link relation type.
+// refers to a resource associated with a time interval whose end coincides with the beginning of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalMetBy].
+const IntervalMetBy string = "intervalMetBy"
+
+// IntervalOverlappedBy is the IANA registered "intervalOverlappedBy" link relation type.
+// refers to a resource associated with a time interval that begins before the beginning of the time interval associated with the context resource, and ends after the beginning of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalOverlappedBy].
+const IntervalOverlappedBy string = "intervalOverlappedBy"
+
+// IntervalOverlaps is the IANA registered "intervalOverlaps" link relation type.
+// refers to a resource associated with a time interval that begins before the end of the time interval associated with the context resource, and ends after the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalOverlaps].
+const IntervalOverlaps string = "intervalOverlaps"
+
+// IntervalStartedBy is the IANA registered "intervalStartedBy" link relation type.
+// refers to a resource associated with a time interval whose beginning coincides with the beginning of the time interval associated with the context resource, and ends before the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalStartedBy].
+const IntervalStartedBy string = "intervalStartedBy"
+
+// IntervalStarts is the IANA registered "intervalStarts" link relation type.
+// refers to a resource associated with a time interval whose beginning coincides with the beginning of the time interval associated with the context resource, and ends after the end of the time interval associated with the context resource
+// See [https://www.w3.org/TR/owl-time/#time:intervalStarts].
+const IntervalStarts string = "intervalStarts"
+
+// Item is the IANA registered "item" link relation type.
+// The target IRI points to a resource that is a member of the collection represented by the context IRI.
+// See [RFC6573].
+const Item string = "item"
+
+// Last is the IANA registered "last" link relation type.
+// An IRI that refers to the furthest following resource in a series of resources.
+// See [RFC8288].
+const Last string = "last"
+
+// LatestVersion is the IANA registered "latest-version" link relation type.
+// Points to a resource containing the latest (e.g., current) version of the context.
+// See [RFC5829].
+const LatestVersion string = "latest-version"
+
+// License is the IANA registered "license" link relation type.
+// Refers to a license associated with this context.
+// See [RFC4946].
+const License string = "license"
+
+// Linkset is the IANA registered "linkset" link relation type.
+// The link target of a link with the "linkset" relation type provides a set of links, including links in which the link context of the link participates.
+// See [RFC9264].
+const Linkset string = "linkset"
+
+// Lrdd is the IANA registered "lrdd" link relation type.
+// Refers to further information about the link's context, expressed as a LRDD ("Link-based Resource Descriptor Document") resource.
+// See [RFC6415].
+const Lrdd string = "lrdd"
+
+// Manifest is the IANA registered "manifest" link relation type.
+// Links to a manifest file for the context.
+// See [https://www.w3.org/TR/appmanifest/].
+const Manifest string = "manifest"
+
+// MaskIcon is the IANA registered "mask-icon" link relation type.
+// Refers to a mask that can be applied to the icon for the context.
+// See [https://developer.apple.com/library/archive/documentation/AppleApplications/Reference/SafariWebContent/pinnedTabs/pinnedTabs.html].
+const MaskIcon string = "mask-icon"
+
+// Me is the IANA registered "me" link relation type.
+// Indicates that the link target is the resource owner represented by the link context.
+// See [https://microformats.org/wiki/rel-me].
+const Me string = "me"
+
+// MediaFeed is the IANA registered "media-feed" link relation type.
+// Refers to a feed of personalised media recommendations relevant to the link context.
+// See [https://wicg.github.io/media-feeds/#discovery-of-media-feeds].
+const MediaFeed string = "media-feed"
+
+// Memento is the IANA registered "memento" link relation type.
+// The Target IRI points to a Memento, a fixed resource that will not change state anymore.
+// See [RFC7089].
+const Memento string = "memento"
+
+// Micropub is the IANA registered "micropub" link relation type.
+// Links to the context's Micropub endpoint.
+// See [https://www.w3.org/TR/micropub/].
+const Micropub string = "micropub"
+
+// Modulepreload is the IANA registered "modulepreload" link relation type.
+// Refers to a module that the user agent is to preemptively fetch and store for use in the current context.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-modulepreload].
+const Modulepreload string = "modulepreload"
+
+// Monitor is the IANA registered "monitor" link relation type.
+// Refers to a resource that can be used to monitor changes in an HTTP resource.
+// See [RFC5989].
+const Monitor string = "monitor"
+
+// MonitorGroup is the IANA registered "monitor-group" link relation type.
+// Refers to a resource that can be used to monitor changes in a specified group of HTTP resources.
+// See [RFC5989].
+const MonitorGroup string = "monitor-group"
+
+// Next is the IANA registered "next" link relation type.
+// Indicates that the link's context is a part of a series, and that the next in the series is the link target.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-next].
+const Next string = "next"
+
+// NextArchive is the IANA registered "next-archive" link relation type.
+// Refers to the immediately following archive resource.
+// See [RFC5005].
+const NextArchive string = "next-archive"
+
+// Nofollow is the IANA registered "nofollow" link relation type.
+// Indicates that the context’s original author or publisher does not endorse the link target.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-nofollow].
+const Nofollow string = "nofollow"
+
+// Noopener is the IANA registered "noopener" link relation type.
+// Indicates that any newly created top-level browsing context which results from following the link will not be an auxiliary browsing context.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-noopener].
+const Noopener string = "noopener"
+
+// Noreferrer is the IANA registered "noreferrer" link relation type.
+// Indicates that no referrer information is to be leaked when following the link.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-noreferrer].
+const Noreferrer string = "noreferrer"
+
+// Opener is the IANA registered "opener" link relation type.
+// Indicates that any newly created top-level browsing context which results from following the link will be an auxiliary browsing context.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-opener].
+const Opener string = "opener"
+
+// Openid2LocalID is the IANA registered "openid2.local_id" link relation type.
+// Refers to an OpenID Authentication server on which the context relies for an assertion that the end user controls an Identifier.
+// See [https://openid.net/specs/openid-authentication-2_0.html#rfc.section.7.3.3].
+const Openid2LocalID string = "openid2.local_id"
+
+// Openid2Provider is the IANA registered "openid2.provider" link relation type.
+// Refers to a resource which accepts OpenID Authentication protocol messages for the context.
+// See [https://openid.net/specs/openid-authentication-2_0.html#rfc.section.7.3.3].
+const Openid2Provider string = "openid2.provider"
+
+// Original is the IANA registered "original" link relation type.
+// The Target IRI points to an Original Resource.
+// See [RFC7089].
+const Original string = "original"
+
+// P3Pv1 is the IANA registered "P3Pv1" link relation type.
+// Refers to a P3P privacy policy for the context.
+// See [https://www.w3.org/TR/P3P/].
+const P3Pv1 string = "P3Pv1"
+
+// Payment is the IANA registered "payment" link relation type.
+// Indicates a resource where payment is accepted.
+// See [RFC8288].
+const Payment string = "payment"
+
+// Pingback is the IANA registered "pingback" link relation type.
+// Gives the address of the pingback resource for the link context.
+// See [http://www.hixie.ch/specs/pingback/pingback].
+const Pingback string = "pingback"
+
+// Preconnect is the IANA registered "preconnect" link relation type.
+// Used to indicate an origin that will be used to fetch required resources for the link context. Initiating an early connection, which includes the DNS lookup, TCP handshake, and optional TLS negotiation, allows the user agent to mask the high latency costs of establishing a connection.
+// See [https://www.w3.org/TR/resource-hints/].
+const Preconnect string = "preconnect"
+
+// PredecessorVersion is the IANA registered "predecessor-version" link relation type.
+// Points to a resource containing the predecessor version in the version history.
+// See [RFC5829].
+const PredecessorVersion string = "predecessor-version"
+
+// Prefetch is the IANA registered "prefetch" link relation type.
+// The prefetch link relation type is used to identify a resource that might be required by the next navigation from the link context, and that the user agent ought to fetch, such that the user agent can deliver a faster response once the resource is requested in the future.
+// See [https://www.w3.org/TR/resource-hints/].
+const Prefetch string = "prefetch"
+
+// Preload is the IANA registered "preload" link relation type.
+// Refers to a resource that should be loaded early in the processing of the link's context, without blocking rendering.
+// See [https://www.w3.org/TR/preload/].
+const Preload string = "preload"
+
+// Prerender is the IANA registered "prerender" link relation type.
+// Used to identify a resource that might be required by the next navigation from the link context, and that the user agent ought to fetch and execute, such that the user agent can deliver a faster response once the resource is requested in the future.
+// See [https://www.w3.org/TR/resource-hints/].
+const Prerender string = "prerender"
+
+// Prev is the IANA registered "prev" link relation type.
+// Indicates that the link's context is a part of a series, and that the previous in the series is the link target.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-prev].
+const Prev string = "prev"
+
+// PrevArchive is the IANA registered "prev-archive" link relation type.
+// Refers to the immediately preceding archive resource.
+// See [RFC5005].
+const PrevArchive string = "prev-archive"
+
+// Preview is the IANA registered "preview" link relation type.
+// Refers to a resource that provides a preview of the link's context.
+// See [RFC6903], section 3.
+const Preview string = "preview"
+
+// Previous is the IANA registered "previous" link relation type.
+// Refers to the previous resource in an ordered series of resources. Synonym for "prev".
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Previous string = "previous"
+
+// PrivacyPolicy is the IANA registered "privacy-policy" link relation type.
+// Refers to a privacy policy associated with the link's context.
+// See [RFC6903], section 4.
+const PrivacyPolicy string = "privacy-policy"
+
+// Profile is the IANA registered "profile" link relation type.
+// Identifying that a resource representation conforms to a certain profile, without affecting the non-profile semantics of the resource representation.
+// See [RFC6906].
+const Profile string = "profile"
+
+// Publication is the IANA registered "publication" link relation type.
+// Links to a publication manifest. A manifest represents structured information about a publication, such as informative metadata, a list of resources, and a default reading order.
+// See [https://www.w3.org/TR/pub-manifest/#link-relation-type-registration].
+const Publication string = "publication"
+
+// Related is the IANA registered "related" link relation type.
+// Identifies a related resource.
+// See [RFC4287].
+const Related string = "related"
+
+// Replies is the IANA registered "replies" link relation type.
+// Identifies a resource that is a reply to the context of the link.
+// See [RFC4685].
+const Replies string = "replies"
+
+// Restconf is the IANA registered "restconf" link relation type.
+// Identifies the root of RESTCONF API as configured on this HTTP server. The "restconf" relation defines the root of the API defined in RFC8040. Subsequent revisions of RESTCONF will use alternate relation values to support protocol versioning.
+// See [RFC8040].
+const Restconf string = "restconf"
+
+// Search is the IANA registered "search" link relation type.
+// Refers to a resource that can be used to search through the link's context and related resources.
+// See [http://www.opensearch.org/Specifications/OpenSearch/1.1].
+const Search string = "search"
+
+// Section is the IANA registered "section" link relation type.
+// Refers to a section in a collection of resources.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Section string = "section"
+
+// Self is the IANA registered "self" link relation type.
+// Conveys an identifier for the link's context.
+// See [RFC4287].
+const Self string = "self"
+
+// Service is the IANA registered "service" link relation type.
+// Indicates a URI that can be used to retrieve a service document.
+// See [RFC5023].
+const Service string = "service"
+
+// ServiceDesc is the IANA registered "service-desc" link relation type.
+// Identifies service description for the context that is primarily intended for consumption by machines.
+// See [RFC8631].
+const ServiceDesc string = "service-desc"
+
+// ServiceDoc is the IANA registered "service-doc" link relation type.
+// Identifies service documentation for the context that is primarily intended for human consumption.
+// See [RFC8631].
+const ServiceDoc string = "service-doc"
+
+// ServiceMeta is the IANA registered "service-meta" link relation type.
+// Identifies general metadata for the context that is primarily intended for consumption by machines.
+// See [RFC8631].
+const ServiceMeta string = "service-meta"
+
+// SIPTrunkingCapability is the IANA registered "sip-trunking-capability" link relation type.
+// Refers to a capability set document that defines parameters or configuration requirements for automated peering and communication channel negotiation of the Session Initiation Protocol (SIP).
+// See [RFC9409].
+const SIPTrunkingCapability string = "sip-trunking-capability"
+
+// Sponsored is the IANA registered "sponsored" link relation type.
+// Refers to a resource that is within a context that is sponsored (such as advertising or another compensation agreement).
+// See [https://webmasters.googleblog.com/2019/09/evolving-nofollow-new-ways-to-identify.html].
+const Sponsored string = "sponsored"
+
+// Start is the IANA registered "start" link relation type.
+// Refers to the first resource in a collection of resources.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Start string = "start"
+
+// Status is the IANA registered "status" link relation type.
+// Identifies a resource that represents the context's status.
+// See [RFC8631].
+const Status string = "status"
+
+// Stylesheet is the IANA registered "stylesheet" link relation type.
+// Refers to a stylesheet.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-stylesheet].
+const Stylesheet string = "stylesheet"
+
+// Subsection is the IANA registered "subsection" link relation type.
+// Refers to a resource serving as a subsection in a collection of resources.
+// See [http://www.w3.org/TR/1999/REC-html401-19991224].
+const Subsection string = "subsection"
+
+// SuccessorVersion is the IANA registered "successor-version" link relation type.
+// Points to a resource containing the successor version in the version history.
+// See [RFC5829].
+const SuccessorVersion string = "successor-version"
+
+// Sunset is the IANA registered "sunset" link relation type.
+// Identifies a resource that provides information about the context's retirement policy.
+// See [RFC8594].
+const Sunset string = "sunset"
+
+// Tag is the IANA registered "tag" link relation type.
+// Gives a tag (identified by the given address) that applies to the current document.
+// See [https://html.spec.whatwg.org/multipage/links.html#link-type-tag].
+const Tag string = "tag"
+
+// TermsOfService is the IANA registered "terms-of-service" link relation type.
+// Refers to the terms of service associated with the link's context.
+// See [RFC6903], section 5.
+const TermsOfService string = "terms-of-service"
+
+// Timegate is the IANA registered "timegate" link relation type.
+// The Target IRI points to a TimeGate for an Original Resource.
+// See [RFC7089].
+const Timegate string = "timegate"
+
+// Timemap is the IANA registered "timemap" link relation type.
+// The Target IRI points to a TimeMap for an Original Resource.
+// See [RFC7089].
+const Timemap string = "timemap"
+
+// Type is the IANA registered "type" link relation type.
+// Refers to a resource identifying the abstract semantic type of which the link's context is considered to be an instance.
+// See [RFC6903], section 6.
+const Type string = "type"
+
+// Ugc is the IANA registered "ugc" link relation type.
+// Refers to a resource that is within a context that is User Generated Content.
+// See [https://webmasters.googleblog.com/2019/09/evolving-nofollow-new-ways-to-identify.html].
+const Ugc string = "ugc"
+
+// Up is the IANA registered "up" link relation type.
+// Refers to a parent document in a hierarchy of documents.
+// See [RFC8288].
+const Up string = "up"
+
+// VersionHistory is the IANA registered "version-history" link relation type.
+// Points to a resource containing the version history for the context.
+// See [RFC5829].
+const VersionHistory string = "version-history"
+
+// Via is the IANA registered "via" link relation type.
+// Identifies a resource that is the source of the information in the link's context.
+// See [RFC4287].
+const Via string = "via"
+
+// Webmention is the IANA registered "webmention" link relation type.
+// Identifies a target URI that supports the Webmention protocol. This allows clients that mention a resource in some form of publishing process to contact that endpoint and inform it that this resource has been mentioned.
+// See [https://www.w3.org/TR/webmention/].
+const Webmention string = "webmention"
+
+// WorkingCopy is the IANA registered "working-copy" link relation type.
+// Points to a working copy for this resource.
+// See [RFC5829].
+const WorkingCopy string = "working-copy"
+
+// WorkingCopyOf is the IANA registered "working-copy-of" link relation type.
+// Points to the versioned resource from which this working copy was obtained.
+// See [RFC5829].
+const WorkingCopyOf string = "working-copy-of"
+
+// registrations contains all IANA registered link relation types by lower case name.
+var registrations = map[string]Registration{
+	"about":                     {Name: About, Description: "Refers to a resource that is the subject of the link's context.", Reference: "[RFC6903], section 2"},
+	"acl":                       {Name: ACL, Description: "Asserts that the link target provides an access control description for the link context.", Reference: "[https://solidproject.org/TR/protocol#link-relation-acl]"},
+	"alternate":                 {Name: Alternate, Description: "Refers to a substitute for this context", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-alternate]"},
+	"amphtml":                   {Name: Amphtml, Description: "Used to reference alternative content that uses the AMP profile of the HTML format.", Reference: "[https://amp.dev/documentation/guides-and-tutorials/learn/spec/amphtml/]"},
+	"api-catalog":               {Name: APICatalog, Description: "Refers to a list of APIs available from the publisher of the link context.", Reference: "[RFC9727]"},
+	"appendix":                  {Name: Appendix, Description: "Refers to an appendix.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"apple-touch-icon":          {Name: AppleTouchIcon, Description: "Refers to an icon for the context. Synonym for icon.", Reference: "[https://developer.apple.com/library/archive/documentation/AppleApplications/Reference/SafariWebContent/ConfiguringWebApplications/ConfiguringWebApplications.html]"},
+	"apple-touch-startup-image": {Name: AppleTouchStartupImage, Description: "Refers to a launch screen for the context.", Reference: "[https://developer.apple.com/library/archive/documentation/AppleApplications/Reference/SafariWebContent/ConfiguringWebApplications/ConfiguringWebApplications.html]"},
+	"archives":                  {Name: Archives, Description: "Refers to a collection of records, documents, or other materials of historical interest.", Reference: "[http://www.w3.org/TR/2011/WD-html5-20110113/links.html#rel-archives]"},
+	"author":                    {Name: Author, Description: "Refers to the context's author.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-author]"},
+	"blocked-by":                {Name: BlockedBy, Description: "Identifies the entity that blocks access to a resource following receipt of a legal demand.", Reference: "[RFC7725]"},
+	"bookmark":                  {Name: Bookmark, Description: "Gives a permanent link to use for bookmarking purposes.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-bookmark]"},
+	"canonical":                 {Name: Canonical, Description: "Designates the preferred version of a resource (the IRI and its contents).", Reference: "[RFC6596]"},
+	"chapter":                   {Name: Chapter, Description: "Refers to a chapter in a collection of resources.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"cite-as":                   {Name: CiteAs, Description: "Indicates that the link target is preferred over the link context for the purpose of permanent citation.", Reference: "[RFC8574]"},
+	"collection":                {Name: Collection, Description: "The target IRI points to a resource which represents the collection resource for the context IRI.", Reference: "[RFC6573]"},
+	"contents":                  {Name: Contents, Description: "Refers to a table of contents.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"convertedfrom":             {Name: ConvertedFrom, Description: "The document linked to was later converted to the document that contains this link relation. For example, an RFC can have a link to the Internet-Draft that became the RFC; in that case, the link relation would be \"convertedFrom\".", Reference: "[RFC7991]"},
+	"copyright":                 {Name: Copyright, Description: "Refers to a copyright statement that applies to the link's context.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"create-form":               {Name: CreateForm, Description: "The target IRI points to a resource where a submission form can be obtained.", Reference: "[RFC6861]"},
+	"current":                   {Name: Current, Description: "Refers to a resource containing the most recent item(s) in a collection of resources.", Reference: "[RFC5005]"},
+	"deprecation":               {Name: Deprecation, Description: "Refers to a resource that provides information about the context's deprecation.", Reference: "[RFC9745]"},
+	"describedby":               {Name: Describedby, Description: "Refers to a resource providing information about the link's context.", Reference: "[http://www.w3.org/TR/powder-dr/#assoc-linking]"},
+	"describes":                 {Name: Describes, Description: "The relationship A 'describes' B asserts that resource A provides a description of resource B. There are no constraints on the format or representation of either A or B, neither are there any further constraints on either resource.", Reference: "[RFC6892]"},
+	"disclosure":                {Name: Disclosure, Description: "Refers to a list of patent disclosures made with respect to material for which 'disclosure' relation is specified.", Reference: "[RFC6579]"},
+	"dns-prefetch":              {Name: DNSPrefetch, Description: "Used to indicate an origin that will be used to fetch required resources for the link context, and that the user agent ought to resolve as early as possible.", Reference: "[https://www.w3.org/TR/resource-hints/]"},
+	"duplicate":                 {Name: Duplicate, Description: "Refers to a resource whose available representations are byte-for-byte identical with the corresponding representations of the context IRI.", Reference: "[RFC6249]"},
+	"edit":                      {Name: Edit, Description: "Refers to a resource that can be used to edit the link's context.", Reference: "[RFC5023]"},
+	"edit-form":                 {Name: EditForm, Description: "The target IRI points to a resource where a submission form for editing associated resource can be obtained.", Reference: "[RFC6861]"},
+	"edit-media":                {Name: EditMedia, Description: "Refers to a resource that can be used to edit media associated with the link's context.", Reference: "[RFC5023]"},
+	"enclosure":                 {Name: Enclosure, Description: "Identifies a related resource that is potentially large and might require special handling.", Reference: "[RFC4287]"},
+	"external":                  {Name: External, Description: "Refers to a resource that is not part of the same site as the current context.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-external]"},
+	"first":                     {Name: First, Description: "An IRI that refers to the furthest preceding resource in a series of resources.", Reference: "[RFC8288]"},
+	"geofeed":                   {Name: Geofeed, Description: "Refers to a geofeed file as described in RFC 8805.", Reference: "[RFC9632]"},
+	"glossary":                  {Name: Glossary, Description: "Refers to a glossary of terms.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"help":                      {Name: Help, Description: "Refers to context-sensitive help.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-help]"},
+	"hosts":                     {Name: Hosts, Description: "Refers to a resource hosted by the server indicated by the link context.", Reference: "[RFC6690]"},
+	"hub":                       {Name: Hub, Description: "Refers to a hub that enables registration for notification of updates to the context.", Reference: "[https://www.w3.org/TR/websub/]"},
+	"ice-server":                {Name: ICEServer, Description: "Conveys the URI of an ICE server in the Link header of a WHIP or WHEP response.", Reference: "[RFC9725]"},
+	"icon":                      {Name: Icon, Description: "Refers to an icon representing the link's context.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-icon]"},
+	"index":                     {Name: Index, Description: "Refers to an index.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"intervalafter":             {Name: IntervalAfter, Description: "refers to a resource associated with a time interval that ends before the beginning of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalAfter]"},
+	"intervalbefore":            {Name: IntervalBefore, Description: "refers to a resource associated with a time interval that begins after the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalBefore]"},
+	"intervalcontains":          {Name: IntervalContains, Description: "refers to a resource associated with a time interval that begins after the beginning of the time interval associated with the context resource, and ends before the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalContains]"},
+	"intervaldisjoint":          {Name: IntervalDisjoint, Description: "refers to a resource associated with a time interval that begins after the end of the time interval associated with the context resource, or ends before the beginning of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalDisjoint]"},
+	"intervalduring":            {Name: IntervalDuring, Description: "refers to a resource associated with a time interval that begins before the beginning of the time interval associated with the context resource, and ends after the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalDuring]"},
+	"intervalequals":            {Name: IntervalEquals, Description: "refers to a resource associated with a time interval whose beginning coincides with the beginning of the time interval associated with the context resource, and whose end coincides with the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalEquals]"},
+	"intervalfinishedby":        {Name: IntervalFinishedBy, Description: "refers to a resource associated with a time interval that begins after the beginning of the time interval associated with the context resource, and whose end coincides with the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalFinishedBy]"},
+	"intervalfinishes":          {Name: IntervalFinishes, Description: "refers to a resource associated with a time interval that begins before the beginning of the time interval associated with the context resource, and whose end coincides with the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalFinishes]"},
+	"intervalin":                {Name: IntervalIn, Description: "refers to a resource associated with a time interval that begins before or is coincident with the beginning of the time interval associated with the context resource, and ends after or is coincident with the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalIn]"},
+	"intervalmeets":             {Name: IntervalMeets, Description: "refers to a resource associated with a time interval whose beginning coincides with the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalMeets]"},
+	"intervalmetby":             {Name: IntervalMetBy, Description: "refers to a resource associated with a time interval whose end coincides with the beginning of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalMetBy]"},
+	"intervaloverlappedby":      {Name: IntervalOverlappedBy, Description: "refers to a resource associated with a time interval that begins before the beginning of the time interval associated with the context resource, and ends after the beginning of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalOverlappedBy]"},
+	"intervaloverlaps":          {Name: IntervalOverlaps, Description: "refers to a resource associated with a time interval that begins before the end of the time interval associated with the context resource, and ends after the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalOverlaps]"},
+	"intervalstartedby":         {Name: IntervalStartedBy, Description: "refers to a resource associated with a time interval whose beginning coincides with the beginning of the time interval associated with the context resource, and ends before the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalStartedBy]"},
+	"intervalstarts":            {Name: IntervalStarts, Description: "refers to a resource associated with a time interval whose beginning coincides with the beginning of the time interval associated with the context resource, and ends after the end of the time interval associated with the context resource", Reference: "[https://www.w3.org/TR/owl-time/#time:intervalStarts]"},
+	"item":                      {Name: Item, Description: "The target IRI points to a resource that is a member of the collection represented by the context IRI.", Reference: "[RFC6573]"},
+	"last":                      {Name: Last, Description: "An IRI that refers to the furthest following resource in a series of resources.", Reference: "[RFC8288]"},
+	"latest-version":            {Name: LatestVersion, Description: "Points to a resource containing the latest (e.g., current) version of the context.", Reference: "[RFC5829]"},
+	"license":                   {Name: License, Description: "Refers to a license associated with this context.", Reference: "[RFC4946]"},
+	"linkset":                   {Name: Linkset, Description: "The link target of a link with the \"linkset\" relation type provides a set of links, including links in which the link context of the link participates.", Reference: "[RFC9264]"},
+	"lrdd":                      {Name: Lrdd, Description: "Refers to further information about the link's context, expressed as a LRDD (\"Link-based Resource Descriptor Document\") resource.", Reference: "[RFC6415]"},
+	"manifest":                  {Name: Manifest, Description: "Links to a manifest file for the context.", Reference: "[https://www.w3.org/TR/appmanifest/]"},
+	"mask-icon":                 {Name: MaskIcon, Description: "Refers to a mask that can be applied to the icon for the context.", Reference: "[https://developer.apple.com/library/archive/documentation/AppleApplications/Reference/SafariWebContent/pinnedTabs/pinnedTabs.html]"},
+	"me":                        {Name: Me, Description: "Indicates that the link target is the resource owner represented by the link context.", Reference: "[https://microformats.org/wiki/rel-me]"},
+	"media-feed":                {Name: MediaFeed, Description: "Refers to a feed of personalised media recommendations relevant to the link context.", Reference: "[https://wicg.github.io/media-feeds/#discovery-of-media-feeds]"},
+	"memento":                   {Name: Memento, Description: "The Target IRI points to a Memento, a fixed resource that will not change state anymore.", Reference: "[RFC7089]"},
+	"micropub":                  {Name: Micropub, Description: "Links to the context's Micropub endpoint.", Reference: "[https://www.w3.org/TR/micropub/]"},
+	"modulepreload":             {Name: Modulepreload, Description: "Refers to a module that the user agent is to preemptively fetch and store for use in the current context.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-modulepreload]"},
+	"monitor":                   {Name: Monitor, Description: "Refers to a resource that can be used to monitor changes in an HTTP resource.", Reference: "[RFC5989]"},
+	"monitor-group":             {Name: MonitorGroup, Description: "Refers to a resource that can be used to monitor changes in a specified group of HTTP resources.", Reference: "[RFC5989]"},
+	"next":                      {Name: Next, Description: "Indicates that the link's context is a part of a series, and that the next in the series is the link target.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-next]"},
+	"next-archive":              {Name: NextArchive, Description: "Refers to the immediately following archive resource.", Reference: "[RFC5005]"},
+	"nofollow":                  {Name: Nofollow, Description: "Indicates that the context’s original author or publisher does not endorse the link target.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-nofollow]"},
+	"noopener":                  {Name: Noopener, Description: "Indicates that any newly created top-level browsing context which results from following the link will not be an auxiliary browsing context.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-noopener]"},
+	"noreferrer":                {Name: Noreferrer, Description: "Indicates that no referrer information is to be leaked when following the link.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-noreferrer]"},
+	"opener":                    {Name: Opener, Description: "Indicates that any newly created top-level browsing context which results from following the link will be an auxiliary browsing context.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-opener]"},
+	"openid2.local_id":          {Name: Openid2LocalID, Description: "Refers to an OpenID Authentication server on which the context relies for an assertion that the end user controls an Identifier.", Reference: "[https://openid.net/specs/openid-authentication-2_0.html#rfc.section.7.3.3]"},
+	"openid2.provider":          {Name: Openid2Provider, Description: "Refers to a resource which accepts OpenID Authentication protocol messages for the context.", Reference: "[https://openid.net/specs/openid-authentication-2_0.html#rfc.section.7.3.3]"},
+	"original":                  {Name: Original, Description: "The Target IRI points to an Original Resource.", Reference: "[RFC7089]"},
+	"p3pv1":                     {Name: P3Pv1, Description: "Refers to a P3P privacy policy for the context.", Reference: "[https://www.w3.org/TR/P3P/]"},
+	"payment":                   {Name: Payment, Description: "Indicates a resource where payment is accepted.", Reference: "[RFC8288]"},
+	"pingback":                  {Name: Pingback, Description: "Gives the address of the pingback resource for the link context.", Reference: "[http://www.hixie.ch/specs/pingback/pingback]"},
+	"preconnect":                {Name: Preconnect, Description: "Used to indicate an origin that will be used to fetch required resources for the link context. Initiating an early connection, which includes the DNS lookup, TCP handshake, and optional TLS negotiation, allows the user agent to mask the high latency costs of establishing a connection.", Reference: "[https://www.w3.org/TR/resource-hints/]"},
+	"predecessor-version":       {Name: PredecessorVersion, Description: "Points to a resource containing the predecessor version in the version history.", Reference: "[RFC5829]"},
+	"prefetch":                  {Name: Prefetch, Description: "The prefetch link relation type is used to identify a resource that might be required by the next navigation from the link context, and that the user agent ought to fetch, such that the user agent can deliver a faster response once the resource is requested in the future.", Reference: "[https://www.w3.org/TR/resource-hints/]"},
+	"preload":                   {Name: Preload, Description: "Refers to a resource that should be loaded early in the processing of the link's context, without blocking rendering.", Reference: "[https://www.w3.org/TR/preload/]"},
+	"prerender":                 {Name: Prerender, Description: "Used to identify a resource that might be required by the next navigation from the link context, and that the user agent ought to fetch and execute, such that the user agent can deliver a faster response once the resource is requested in the future.", Reference: "[https://www.w3.org/TR/resource-hints/]"},
+	"prev":                      {Name: Prev, Description: "Indicates that the link's context is a part of a series, and that the previous in the series is the link target.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-prev]"},
+	"prev-archive":              {Name: PrevArchive, Description: "Refers to the immediately preceding archive resource.", Reference: "[RFC5005]"},
+	"preview":                   {Name: Preview, Description: "Refers to a resource that provides a preview of the link's context.", Reference: "[RFC6903], section 3"},
+	"previous":                  {Name: Previous, Description: "Refers to the previous resource in an ordered series of resources. Synonym for \"prev\".", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"privacy-policy":            {Name: PrivacyPolicy, Description: "Refers to a privacy policy associated with the link's context.", Reference: "[RFC6903], section 4"},
+	"profile":                   {Name: Profile, Description: "Identifying that a resource representation conforms to a certain profile, without affecting the non-profile semantics of the resource representation.", Reference: "[RFC6906]"},
+	"publication":               {Name: Publication, Description: "Links to a publication manifest. A manifest represents structured information about a publication, such as informative metadata, a list of resources, and a default reading order.", Reference: "[https://www.w3.org/TR/pub-manifest/#link-relation-type-registration]"},
+	"related":                   {Name: Related, Description: "Identifies a related resource.", Reference: "[RFC4287]"},
+	"replies":                   {Name: Replies, Description: "Identifies a resource that is a reply to the context of the link.", Reference: "[RFC4685]"},
+	"restconf":                  {Name: Restconf, Description: "Identifies the root of RESTCONF API as configured on this HTTP server. The \"restconf\" relation defines the root of the API defined in RFC8040. Subsequent revisions of RESTCONF will use alternate relation values to support protocol versioning.", Reference: "[RFC8040]"},
+	"search":                    {Name: Search, Description: "Refers to a resource that can be used to search through the link's context and related resources.", Reference: "[http://www.opensearch.org/Specifications/OpenSearch/1.1]"},
+	"section":                   {Name: Section, Description: "Refers to a section in a collection of resources.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"self":                      {Name: Self, Description: "Conveys an identifier for the link's context.", Reference: "[RFC4287]"},
+	"service":                   {Name: Service, Description: "Indicates a URI that can be used to retrieve a service document.", Reference: "[RFC5023]"},
+	"service-desc":              {Name: ServiceDesc, Description: "Identifies service description for the context that is primarily intended for consumption by machines.", Reference: "[RFC8631]"},
+	"service-doc":               {Name: ServiceDoc, Description: "Identifies service documentation for the context that is primarily intended for human consumption.", Reference: "[RFC8631]"},
+	"service-meta":              {Name: ServiceMeta, Description: "Identifies general metadata for the context that is primarily intended for consumption by machines.", Reference: "[RFC8631]"},
+	"sip-trunking-capability":   {Name: SIPTrunkingCapability, Description: "Refers to a capability set document that defines parameters or configuration requirements for automated peering and communication channel negotiation of the Session Initiation Protocol (SIP).", Reference: "[RFC9409]"},
+	"sponsored":                 {Name: Sponsored, Description: "Refers to a resource that is within a context that is sponsored (such as advertising or another compensation agreement).", Reference: "[https://webmasters.googleblog.com/2019/09/evolving-nofollow-new-ways-to-identify.html]"},
+	"start":                     {Name: Start, Description: "Refers to the first resource in a collection of resources.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"status":                    {Name: Status, Description: "Identifies a resource that represents the context's status.", Reference: "[RFC8631]"},
+	"stylesheet":                {Name: Stylesheet, Description: "Refers to a stylesheet.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-stylesheet]"},
+	"subsection":                {Name: Subsection, Description: "Refers to a resource serving as a subsection in a collection of resources.", Reference: "[http://www.w3.org/TR/1999/REC-html401-19991224]"},
+	"successor-version":         {Name: SuccessorVersion, Description: "Points to a resource containing the successor version in the version history.", Reference: "[RFC5829]"},
+	"sunset":                    {Name: Sunset, Description: "Identifies a resource that provides information about the context's retirement policy.", Reference: "[RFC8594]"},
+	"tag":                       {Name: Tag, Description: "Gives a tag (identified by the given address) that applies to the current document.", Reference: "[https://html.spec.whatwg.org/multipage/links.html#link-type-tag]"},
+	"terms-of-service":          {Name: TermsOfService, Description: "Refers to the terms of service associated with the link's context.", Reference: "[RFC6903], section 5"},
+	"timegate":                  {Name: Timegate, Description: "The Target IRI points to a TimeGate for an Original Resource.", Reference: "[RFC7089]"},
+	"timemap":                   {Name: Timemap, Description: "The Target IRI points to a TimeMap for an Original Resource.", Reference: "[RFC7089]"},
+	"type":                      {Name: Type, Description: "Refers to a resource identifying the abstract semantic type of which the link's context is considered to be an instance.", Reference: "[RFC6903], section 6"},
+	"ugc":                       {Name: Ugc, Description: "Refers to a resource that is within a context that is User Generated Content.", Reference: "[https://webmasters.googleblog.com/2019/09/evolving-nofollow-new-ways-to-identify.html]"},
+	"up":                        {Name: Up, Description: "Refers to a parent document in a hierarchy of documents.", Reference: "[RFC8288]"},
+	"version-history":           {Name: VersionHistory, Description: "Points to a resource containing the version history for the context.", Reference: "[RFC5829]"},
+	"via":                       {Name: Via, Description: "Identifies a resource that is the source of the information in the link's context.", Reference: "[RFC4287]"},
+	"webmention":                {Name: Webmention, Description: "Identifies a target URI that supports the Webmention protocol. This allows clients that mention a resource in some form of publishing process to contact that endpoint and inform it that this resource has been mentioned.", Reference: "[https://www.w3.org/TR/webmention/]"},
+	"working-copy":              {Name: WorkingCopy, Description: "Points to a working copy for this resource.", Reference: "[RFC5829]"},
+	"working-copy-of":           {Name: WorkingCopyOf, Description: "Points to the versioned resource from which this working copy was obtained.", Reference: "[RFC5829]"},
+}
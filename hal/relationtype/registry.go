@@ -0,0 +1,251 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package relationtype
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pmoule/go2hal/hal/uritemplate"
+)
+
+// Errors reported by a Registry. Use errors.Is to check for them.
+var (
+	ErrUnknownRelation = errors.New("unknown relation type")
+	ErrUnknownCurie    = errors.New("unknown CURIE")
+	ErrInvalidUsage    = errors.New("relation type not allowed here")
+	ErrAlreadyDefined  = errors.New("already defined")
+)
+
+// Usage defines whether a relation type is used for links, embedded resources or both.
+type Usage int
+
+const (
+	// LinkOrEmbedded relation types are used for links and embedded resources.
+	LinkOrEmbedded Usage = iota
+	// LinkOnly relation types are used for links only.
+	LinkOnly
+	// EmbeddedOnly relation types are used for embedded resources only.
+	EmbeddedOnly
+)
+
+// Curie is a CURIE namespace. Href is a URI Template with a variable rel, e.g.
+// http://example.com/docs/relations/{rel}.
+type Curie struct {
+	Name string
+	Href string
+}
+
+// Definition describes a custom relation type.
+type Definition struct {
+	// Name of the relation type, without CURIE prefix, e.g. widgets.
+	Name string
+	// Curie is the name of the CURIE namespace the relation type belongs to, e.g. acme.
+	// Relation types without CURIE namespace are URIs or plain names.
+	Curie       string
+	Description string
+	Usage       Usage
+}
+
+// FullName returns the name prefixed by the CURIE name, e.g. acme:widgets.
+func (d Definition) FullName() string {
+	if d.Curie == "" {
+		return d.Name
+	}
+
+	return d.Curie + ":" + d.Name
+}
+
+// Registry holds custom relation types and the CURIE namespaces they belong to.
+// IANA registered relation types are known without registration.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mutex       sync.RWMutex
+	curies      map[string]Curie
+	curieNames  []string
+	definitions map[string]Definition
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{curies: map[string]Curie{}, definitions: map[string]Definition{}}
+}
+
+// RegisterCurie adds a CURIE namespace. The href must be a URI Template with a variable rel.
+func (r *Registry) RegisterCurie(name string, href string) error {
+	if name == "" || strings.Contains(name, ":") {
+		return fmt.Errorf("invalid CURIE name %q", name)
+	}
+
+	if _, err := uritemplate.Parse(href); err != nil {
+		return fmt.Errorf("CURIE %s: %w", name, err)
+	}
+
+	if strings.Count(href, "{rel}") != 1 {
+		return fmt.Errorf("CURIE %s: href %q requires a single {rel} expression", name, href)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.curies[name]; ok {
+		return fmt.Errorf("CURIE %s: %w", name, ErrAlreadyDefined)
+	}
+
+	r.curies[name] = Curie{Name: name, Href: href}
+	r.curieNames = append(r.curieNames, name)
+
+	return nil
+}
+
+// Register adds a custom relation type. Its CURIE namespace must be registered before.
+func (r *Registry) Register(definition Definition) error {
+	if definition.Name == "" || (definition.Curie != "" && strings.Contains(definition.Name, ":")) {
+		return fmt.Errorf("invalid relation type name %q", definition.Name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.curies[definition.Curie]; definition.Curie != "" && !ok {
+		return fmt.Errorf("relation type %s: %w %s", definition.FullName(), ErrUnknownCurie, definition.Curie)
+	}
+
+	if _, ok := r.definitions[definition.FullName()]; ok {
+		return fmt.Errorf("relation type %s: %w", definition.FullName(), ErrAlreadyDefined)
+	}
+
+	r.definitions[definition.FullName()] = definition
+
+	return nil
+}
+
+// Curie returns a registered CURIE namespace.
+func (r *Registry) Curie(name string) (Curie, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	curie, ok := r.curies[name]
+
+	return curie, ok
+}
+
+// Curies returns all registered CURIE namespaces in registration order.
+func (r *Registry) Curies() []Curie {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	curies := make([]Curie, 0, len(r.curieNames))
+
+	for _, name := range r.curieNames {
+		curies = append(curies, r.curies[name])
+	}
+
+	return curies
+}
+
+// Lookup returns a relation type by its full name, e.g. acme:widgets, or its expanded URI.
+// IANA registered relation types are returned with their description.
+func (r *Registry) Lookup(name string) (Definition, bool) {
+	if registration, ok := Lookup(name); ok {
+		return Definition{Name: registration.Name, Description: registration.Description}, true
+	}
+
+	r.mutex.RLock()
+	definition, ok := r.definitions[name]
+	r.mutex.RUnlock()
+
+	if ok {
+		return definition, true
+	}
+
+	if compact, err := r.Compact(name); err == nil {
+		r.mutex.RLock()
+		defer r.mutex.RUnlock()
+
+		definition, ok = r.definitions[compact]
+	}
+
+	return definition, ok
+}
+
+// ResolveLink returns a relation type used for links. Unknown relation types and relation
+// types restricted to embedded resources are reported as error.
+func (r *Registry) ResolveLink(name string) (Definition, error) {
+	return r.resolve(name, EmbeddedOnly)
+}
+
+// ResolveEmbedded returns a relation type used for embedded resources. Unknown relation types and
+// relation types restricted to links are reported as error.
+func (r *Registry) ResolveEmbedded(name string) (Definition, error) {
+	return r.resolve(name, LinkOnly)
+}
+
+func (r *Registry) resolve(name string, forbidden Usage) (Definition, error) {
+	if prefix, _, ok := splitCurie(name); ok {
+		if _, ok := r.Curie(prefix); !ok {
+			return Definition{}, fmt.Errorf("relation type %s: %w %s", name, ErrUnknownCurie, prefix)
+		}
+	}
+
+	definition, ok := r.Lookup(name)
+
+	if !ok {
+		return Definition{}, fmt.Errorf("%w %s", ErrUnknownRelation, name)
+	}
+
+	if definition.Usage == forbidden {
+		return Definition{}, fmt.Errorf("relation type %s: %w", name, ErrInvalidUsage)
+	}
+
+	return definition, nil
+}
+
+// Expand returns the URI of a compact relation type name, e.g. acme:widgets becomes
+// http://acme.com/relations/widgets. Names without CURIE prefix are returned unchanged.
+func (r *Registry) Expand(name string) (string, error) {
+	prefix, reference, ok := splitCurie(name)
+
+	if !ok {
+		return name, nil
+	}
+
+	curie, ok := r.Curie(prefix)
+
+	if !ok {
+		return "", fmt.Errorf("relation type %s: %w %s", name, ErrUnknownCurie, prefix)
+	}
+
+	return uritemplate.Expand(curie.Href, map[string]interface{}{"rel": reference})
+}
+
+// Compact returns the compact name of a relation type URI, e.g. http://acme.com/relations/widgets
+// becomes acme:widgets. If no registered CURIE namespace matches, an error is returned.
+func (r *Registry) Compact(uri string) (string, error) {
+	for _, curie := range r.Curies() {
+		index := strings.Index(curie.Href, "{rel}")
+		prefix, suffix := curie.Href[:index], curie.Href[index+len("{rel}"):]
+
+		if len(uri) > len(prefix)+len(suffix) && strings.HasPrefix(uri, prefix) && strings.HasSuffix(uri, suffix) {
+			return curie.Name + ":" + uri[len(prefix):len(uri)-len(suffix)], nil
+		}
+	}
+
+	return "", fmt.Errorf("relation type %s: no matching CURIE", uri)
+}
+
+// splitCurie splits a compact name into CURIE prefix and reference. URIs with "://" and URNs
+// are no compact names.
+func splitCurie(name string) (string, string, bool) {
+	index := strings.Index(name, ":")
+
+	if index <= 0 || strings.HasPrefix(name[index:], "://") || strings.EqualFold(name[:index], "urn") {
+		return "", "", false
+	}
+
+	return name[:index], name[index+1:], true
+}
@@ -0,0 +1,192 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package relationtype
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	registry := NewRegistry()
+
+	if err := registry.RegisterCurie("acme", "http://acme.com/relations/{rel}"); err != nil {
+		t.Fatalf("RegisterCurie returned error: %v", err)
+	}
+
+	definitions := []Definition{
+		{Name: "widgets", Curie: "acme", Description: "Widgets of a shop."},
+		{Name: "search", Curie: "acme", Usage: LinkOnly},
+		{Name: "items", Curie: "acme", Usage: EmbeddedOnly},
+		{Name: "http://example.com/rels/orders"},
+	}
+
+	for _, definition := range definitions {
+		if err := registry.Register(definition); err != nil {
+			t.Fatalf("Register returned error: %v", err)
+		}
+	}
+
+	return registry
+}
+
+func TestRegisterCurie(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	invalid := []struct {
+		name string
+		href string
+	}{
+		{"", "http://acme.com/{rel}"},
+		{"a:b", "http://acme.com/{rel}"},
+		{"other", "http://acme.com/relations"},
+		{"other", "http://acme.com/{rel}/{rel}"},
+		{"other", "http://acme.com/{rel"},
+	}
+
+	for _, curie := range invalid {
+		if err := registry.RegisterCurie(curie.name, curie.href); err == nil {
+			t.Errorf("RegisterCurie(%q, %q) should return error", curie.name, curie.href)
+		}
+	}
+
+	err := registry.RegisterCurie("acme", "http://acme.com/other/{rel}")
+
+	if !errors.Is(err, ErrAlreadyDefined) {
+		t.Errorf("Error is %v, want %v", err, ErrAlreadyDefined)
+	}
+
+	registry.RegisterCurie("beta", "http://beta.com/{rel}")
+	curies := registry.Curies()
+
+	if len(curies) != 2 || curies[0].Name != "acme" || curies[1].Name != "beta" {
+		t.Errorf("Curies are %v, want acme and beta in registration order", curies)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	err := registry.Register(Definition{Name: "widgets", Curie: "unknown"})
+
+	if !errors.Is(err, ErrUnknownCurie) {
+		t.Errorf("Error is %v, want %v", err, ErrUnknownCurie)
+	}
+
+	err = registry.Register(Definition{Name: "widgets", Curie: "acme"})
+
+	if !errors.Is(err, ErrAlreadyDefined) {
+		t.Errorf("Error is %v, want %v", err, ErrAlreadyDefined)
+	}
+
+	if err := registry.Register(Definition{Name: ""}); err == nil {
+		t.Errorf("Register should return error for empty name")
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	names := []string{"acme:widgets", "http://acme.com/relations/widgets"}
+
+	for _, name := range names {
+		definition, ok := registry.Lookup(name)
+
+		if !ok {
+			t.Fatalf("Lookup should find %s", name)
+		}
+
+		if definition.FullName() != "acme:widgets" {
+			t.Errorf("Full name is %s, want %s", definition.FullName(), "acme:widgets")
+		}
+
+		if definition.Description != "Widgets of a shop." {
+			t.Errorf("Description is %q, want %q", definition.Description, "Widgets of a shop.")
+		}
+	}
+
+	definition, ok := registry.Lookup(Next)
+
+	if !ok || definition.Name != Next || definition.Description == "" {
+		t.Errorf("Lookup should find IANA relation type %s: %v", Next, definition)
+	}
+
+	if _, ok := registry.Lookup("acme:unknown"); ok {
+		t.Errorf("Lookup should not find %s", "acme:unknown")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	tests := []struct {
+		name     string
+		link     error
+		embedded error
+	}{
+		{"acme:widgets", nil, nil},
+		{"acme:search", nil, ErrInvalidUsage},
+		{"acme:items", ErrInvalidUsage, nil},
+		{"http://acme.com/relations/items", ErrInvalidUsage, nil},
+		{"http://example.com/rels/orders", nil, nil},
+		{Self, nil, nil},
+		{"acme:unknown", ErrUnknownRelation, ErrUnknownRelation},
+		{"other:widgets", ErrUnknownCurie, ErrUnknownCurie},
+		{"widgets", ErrUnknownRelation, ErrUnknownRelation},
+	}
+
+	for _, test := range tests {
+		if _, err := registry.ResolveLink(test.name); !errors.Is(err, test.link) {
+			t.Errorf("ResolveLink(%s) error is %v, want %v", test.name, err, test.link)
+		}
+
+		if _, err := registry.ResolveEmbedded(test.name); !errors.Is(err, test.embedded) {
+			t.Errorf("ResolveEmbedded(%s) error is %v, want %v", test.name, err, test.embedded)
+		}
+	}
+}
+
+func TestExpandAndCompact(t *testing.T) {
+	registry := newTestRegistry(t)
+	registry.RegisterCurie("docs", "http://docs.acme.com/{rel}.html")
+
+	tests := []struct {
+		compact  string
+		expanded string
+	}{
+		{"acme:widgets", "http://acme.com/relations/widgets"},
+		{"docs:orders", "http://docs.acme.com/orders.html"},
+	}
+
+	for _, test := range tests {
+		expanded, err := registry.Expand(test.compact)
+
+		if err != nil || expanded != test.expanded {
+			t.Errorf("Expand(%s) is %s, %v, want %s", test.compact, expanded, err, test.expanded)
+		}
+
+		compact, err := registry.Compact(test.expanded)
+
+		if err != nil || compact != test.compact {
+			t.Errorf("Compact(%s) is %s, %v, want %s", test.expanded, compact, err, test.compact)
+		}
+	}
+
+	unchanged := []string{Self, "http://example.com/rels/orders", "urn:example:orders"}
+
+	for _, name := range unchanged {
+		if expanded, err := registry.Expand(name); err != nil || expanded != name {
+			t.Errorf("Expand(%s) is %s, %v, want %s", name, expanded, err, name)
+		}
+	}
+
+	if _, err := registry.Expand("other:widgets"); !errors.Is(err, ErrUnknownCurie) {
+		t.Errorf("Error is %v, want %v", err, ErrUnknownCurie)
+	}
+
+	if _, err := registry.Compact("http://example.com/rels/orders"); err == nil {
+		t.Errorf("Compact should return error for URI without matching CURIE")
+	}
+}
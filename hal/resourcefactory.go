@@ -4,7 +4,14 @@
 
 package hal
 
+import (
+	"fmt"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
 // ResourceFactory is a helper for creating resources and links.
+// ResourceFactories created by this package implement ResourceFactoryE as well.
 type ResourceFactory interface {
 	CreateRootResource(href string) Resource
 	CreateEmbeddedResource(href string) Resource
@@ -12,106 +19,126 @@ type ResourceFactory interface {
 	CreateResourceLink(relationName string, curieLinkName string) ResourceRelation
 }
 
+// ResourceFactoryE is a ResourceFactory reporting invalid relations as error -
+// CreateLinkE and CreateResourceLinkE.
+type ResourceFactoryE interface {
+	ResourceFactory
+	CreateLinkE(relationName string, href string, curieLinkName string) (LinkRelation, error)
+	CreateResourceLinkE(relationName string, curieLinkName string) (ResourceRelation, error)
+}
+
 type resourceFactory struct {
-	curieLinks map[string]*LinkObject
+	factory *Factory
 }
 
 // NewResourceFactory initialises a ResourceFactory with a set of CURIE links.
+// Use NewFactory to get errors reported instead of nil values.
 func NewResourceFactory(curieLinks []*LinkObject) ResourceFactory {
-	links := make(map[string]*LinkObject)
+	factory := &Factory{curieLinks: make(map[string]*LinkObject), arrayRelations: map[string]bool{}}
 
 	for _, link := range curieLinks {
-		links[link.Name] = link
+		factory.addCurieLink(link)
 	}
 
-	factory := &resourceFactory{curieLinks: links}
-	return factory
+	return factory.ResourceFactory()
 }
 
-// CreateLink creates a Link Relation with provided relation name and href. A CURIE link can
-// be added by curieLinkName. The real CURIE link is picked from the set of CURIE links the factory
-// is initialised with.
-func (rf *resourceFactory) CreateLink(relationName string, href string, curieLinkName string) LinkRelation {
-	link, linkError := NewLinkObject(href)
+// NewResourceFactoryWithRegistry initialises a ResourceFactory with the CURIE namespaces of a
+// relationtype.Registry. Relations are checked against the registry: unknown relation types,
+// unknown CURIEs and relation types used the wrong way are reported as error by CreateLinkE and
+// CreateResourceLinkE. CreateLink and CreateResourceLink return nil in this case.
+func NewResourceFactoryWithRegistry(registry *relationtype.Registry) (ResourceFactoryE, error) {
+	factory, err := NewFactory(WithCurieRegistry(registry))
 
-	if linkError != nil {
-		return nil
+	if err != nil {
+		return nil, err
 	}
 
-	relation, relationError := NewLinkRelation(relationName)
-
-	if relationError != nil {
-		return nil
-	}
+	return factory.ResourceFactory(), nil
+}
 
-	relation.SetLink(link)
+// NewCurieLinks creates the CURIE links of all CURIE namespaces of a relationtype.Registry.
+func NewCurieLinks(registry *relationtype.Registry) ([]*LinkObject, error) {
+	curieLinks := []*LinkObject{}
 
-	if curieLinkName != "" {
-		curieLink := rf.curieLinks[curieLinkName]
+	for _, curie := range registry.Curies() {
+		curieLink, err := NewCurieLink(curie.Name, curie.Href)
 
-		if curieLink != nil {
-			relation.SetCurieLink(curieLink)
+		if err != nil {
+			return nil, fmt.Errorf("CURIE %s: %w", curie.Name, err)
 		}
+
+		curieLinks = append(curieLinks, curieLink)
 	}
 
+	return curieLinks, nil
+}
+
+// CreateLink creates a Link Relation with provided relation name and href. A CURIE link can
+// be added by curieLinkName. The real CURIE link is picked from the set of CURIE links the factory
+// is initialised with. An unknown curieLinkName is ignored, use CreateLinkE to get notified.
+func (rf *resourceFactory) CreateLink(relationName string, href string, curieLinkName string) LinkRelation {
+	relation, _ := rf.CreateLinkE(relationName, href, rf.knownCurieLinkName(curieLinkName))
+
 	return relation
 }
 
+// CreateLinkE creates a Link Relation the same way CreateLink does. An invalid href or relation
+// name and an unknown CURIE link are reported as error. For a factory with registry, the
+// relation name can be a compact name or an expanded URI as well.
+func (rf *resourceFactory) CreateLinkE(relationName string, href string, curieLinkName string) (LinkRelation, error) {
+	link, err := rf.factory.NewLink(href)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rf.factory.linkRelation(relationName, curieLinkName, []*LinkObject{link})
+}
+
 // CreateResourceLink creates a Link Relation with provided relation name. A CURIE link can
 // be added by curieLinkName. The real CURIE link is picked from the set of CURIE links the factory
-// is initialised with.
+// is initialised with. An unknown curieLinkName is ignored, use CreateResourceLinkE to get notified.
 func (rf *resourceFactory) CreateResourceLink(relationName string, curieLinkName string) ResourceRelation {
-	relation, relationError := NewResourceRelation(relationName)
+	relation, _ := rf.CreateResourceLinkE(relationName, rf.knownCurieLinkName(curieLinkName))
 
-	if relationError != nil {
-		return nil
-	}
+	return relation
+}
 
-	if curieLinkName != "" {
-		curieLink := rf.curieLinks[curieLinkName]
+// CreateResourceLinkE creates a Resource Relation the same way CreateResourceLink does. An invalid
+// relation name and an unknown CURIE link are reported as error. For a factory with registry, the
+// relation name can be a compact name or an expanded URI as well.
+func (rf *resourceFactory) CreateResourceLinkE(relationName string, curieLinkName string) (ResourceRelation, error) {
+	return rf.factory.resourceRelation(relationName, curieLinkName, nil)
+}
 
-		if curieLink != nil {
-			relation.SetCurieLink(curieLink)
-		}
+// knownCurieLinkName returns curieLinkName, if known. Without registry, unknown CURIE links
+// are ignored for compatibility.
+func (rf *resourceFactory) knownCurieLinkName(curieLinkName string) string {
+	if _, ok := rf.factory.curieLinks[curieLinkName]; !ok && rf.factory.options.registry == nil {
+		return ""
 	}
 
-	return relation
+	return curieLinkName
 }
 
 // CreateRootResource creates a root Resource with self link from provided href.
 // Additionally all CURIE links provided at ResourceFactory initialisation are added.
 func (rf *resourceFactory) CreateRootResource(href string) Resource {
-	resource := rf.createResource(href)
-
-	curieLinks := []*LinkObject{}
-
-	for _, v := range rf.curieLinks {
-		curieLinks = append(curieLinks, v)
-	}
-
-	resource.AddCurieLinks(curieLinks)
+	resource := rf.CreateEmbeddedResource(href)
+	resource.AddCurieLinks(rf.factory.CurieLinks())
 
 	return resource
 }
 
 // CreateEmbeddedResource creates an embedded Resource with self link from provided href.
+// For an invalid href the Resource is created without self link.
 func (rf *resourceFactory) CreateEmbeddedResource(href string) Resource {
-	resource := rf.createResource(href)
-
-	return resource
-}
-
-func (rf *resourceFactory) createResource(href string) Resource {
-	resource := NewResourceObject()
-	selfLink, err := NewLinkObject(href)
+	resource, err := rf.factory.EmbeddedResource(href)
 
 	if err != nil {
-		return resource
+		return NewResourceObject()
 	}
 
-	self := NewSelfLinkRelation()
-	self.SetLink(selfLink)
-	resource.AddLink(self)
-
 	return resource
 }
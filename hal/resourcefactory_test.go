@@ -5,6 +5,7 @@
 package hal
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/pmoule/go2hal/hal/relationtype"
@@ -179,3 +180,104 @@ func TestCreateResourceLink(t *testing.T) {
 		t.Errorf("Full name is %s, wanted %s", link.FullName(), curieLinkName+":"+relationName)
 	}
 }
+
+func TestCreateLinkE(t *testing.T) {
+	curieLinks := []*LinkObject{{Name: "Curie1"}}
+	factory, ok := NewResourceFactory(curieLinks).(ResourceFactoryE)
+
+	if !ok {
+		t.Fatalf("ResourceFactory should implement ResourceFactoryE")
+	}
+
+	if _, err := factory.CreateLinkE("relationName", "", ""); err == nil {
+		t.Errorf("CreateLinkE should return error for empty href")
+	}
+
+	if _, err := factory.CreateLinkE("", "href", ""); err == nil {
+		t.Errorf("CreateLinkE should return error for empty relation name")
+	}
+
+	_, err := factory.CreateLinkE("relationName", "href", "Unknown")
+
+	if !errors.Is(err, relationtype.ErrUnknownCurie) {
+		t.Errorf("Error is %v, want %v", err, relationtype.ErrUnknownCurie)
+	}
+
+	link := factory.CreateLink("relationName", "href", "Unknown")
+
+	if link == nil || link.FullName() != "relationName" {
+		t.Errorf("CreateLink should ignore unknown CURIE link: %v", link)
+	}
+
+	_, err = factory.CreateResourceLinkE("relationName", "Unknown")
+
+	if !errors.Is(err, relationtype.ErrUnknownCurie) {
+		t.Errorf("Error is %v, want %v", err, relationtype.ErrUnknownCurie)
+	}
+}
+
+func TestResourceFactoryWithRegistry(t *testing.T) {
+	registry := relationtype.NewRegistry()
+	registry.RegisterCurie("acme", "http://acme.com/relations/{rel}")
+	registry.RegisterCurie("beta", "http://beta.com/{rel}")
+	registry.Register(relationtype.Definition{Name: "widgets", Curie: "acme"})
+	registry.Register(relationtype.Definition{Name: "search", Curie: "acme", Usage: relationtype.LinkOnly})
+
+	factory, err := NewResourceFactoryWithRegistry(registry)
+
+	if err != nil {
+		t.Fatalf("NewResourceFactoryWithRegistry returned error: %v", err)
+	}
+
+	root := factory.CreateRootResource("/")
+	curies, ok := root.Links().Content[relationtype.CURIES].([]*LinkObject)
+
+	if !ok || len(curies) != 2 || curies[0].Name != "acme" || curies[1].Name != "beta" {
+		t.Errorf("CURIE links are %v, want acme and beta", root.Links().Content[relationtype.CURIES])
+	}
+
+	names := []struct {
+		relationName  string
+		curieLinkName string
+	}{
+		{"widgets", "acme"},
+		{"acme:widgets", ""},
+		{"http://acme.com/relations/widgets", ""},
+	}
+
+	for _, name := range names {
+		link, err := factory.CreateLinkE(name.relationName, "/widgets", name.curieLinkName)
+
+		if err != nil {
+			t.Fatalf("CreateLinkE(%s) returned error: %v", name.relationName, err)
+		}
+
+		if link.FullName() != "acme:widgets" {
+			t.Errorf("Full name is %s, want %s", link.FullName(), "acme:widgets")
+		}
+
+		if link.CurieLink().Name != "acme" {
+			t.Errorf("CURIE link is %s, want acme", link.CurieLink().Name)
+		}
+	}
+
+	if _, err := factory.CreateLinkE(relationtype.Next, "/next", ""); err != nil {
+		t.Errorf("CreateLinkE(%s) returned error: %v", relationtype.Next, err)
+	}
+
+	if _, err := factory.CreateLinkE("unknown", "/", "acme"); !errors.Is(err, relationtype.ErrUnknownRelation) {
+		t.Errorf("Error is %v, want %v", err, relationtype.ErrUnknownRelation)
+	}
+
+	if _, err := factory.CreateLinkE("widgets", "/", "other"); !errors.Is(err, relationtype.ErrUnknownCurie) {
+		t.Errorf("Error is %v, want %v", err, relationtype.ErrUnknownCurie)
+	}
+
+	if _, err := factory.CreateResourceLinkE("search", "acme"); !errors.Is(err, relationtype.ErrInvalidUsage) {
+		t.Errorf("Error is %v, want %v", err, relationtype.ErrInvalidUsage)
+	}
+
+	if link := factory.CreateLink("widgets", "/", "other"); link != nil {
+		t.Errorf("Link should be nil: %v", link)
+	}
+}
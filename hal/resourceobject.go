@@ -5,6 +5,8 @@
 package hal
 
 import (
+	"sort"
+
 	"github.com/pmoule/go2hal/hal/mapping"
 	"github.com/pmoule/go2hal/hal/relationtype"
 )
@@ -18,6 +20,8 @@ import (
 // - have CURIEs - AddCurieLinks([]*LinkObject)
 //
 // - embed other resources - AddResource(ResourceRelation)
+//
+// Resources created by NewResourceObject implement RelationLister and DataAdderE as well.
 type Resource interface {
 	Data() mapping.PropertyMap
 	Links() mapping.NamedMap
@@ -29,10 +33,45 @@ type Resource interface {
 	ToMap() mapping.NamedMap
 }
 
+// RelationLister is implemented by Resources providing their relations in the order they were
+// added. Resources not implementing it are encoded from Links() and EmbeddedResources(), their
+// relations are not available for validation, href rewriting and traversal.
+type RelationLister interface {
+	LinkRelations() []LinkRelation
+	ResourceRelations() []ResourceRelation
+}
+
+// DataAdderE is implemented by Resources reporting data not encodable as JSON as error.
+type DataAdderE interface {
+	AddDataE(interface{}) error
+}
+
+// linkRelationsOf returns the link relations of a Resource implementing RelationLister, nil otherwise.
+func linkRelationsOf(resource Resource) []LinkRelation {
+	if lister, ok := resource.(RelationLister); ok {
+		return lister.LinkRelations()
+	}
+
+	return nil
+}
+
+// resourceRelationsOf returns the resource relations of a Resource implementing RelationLister,
+// nil otherwise.
+func resourceRelationsOf(resource Resource) []ResourceRelation {
+	if lister, ok := resource.(RelationLister); ok {
+		return lister.ResourceRelations()
+	}
+
+	return nil
+}
+
 type resourceObject struct {
-	data     mapping.PropertyMap
-	links    Links
-	embedded embeddedResources
+	data          mapping.PropertyMap
+	dataNames     []string
+	links         Links
+	linkNames     []string
+	embedded      embeddedResources
+	embeddedNames []string
 }
 
 // NewResourceObject initialises a Resource.
@@ -46,12 +85,66 @@ func (r *resourceObject) Data() mapping.PropertyMap {
 }
 
 // AddData assigns any type of data to ResourceObject.
+// Fields not encodable as JSON are skipped.
 func (r *resourceObject) AddData(data interface{}) {
-	value := mapping.MapData(data)
+	value, names := mapping.MapDataInOrder(data)
+	r.addData(value, names)
+}
+
+// AddDataE assigns any type of data to ResourceObject the same way AddData does.
+// If a field is not encodable as JSON, a *mapping.FieldError is returned and no data is assigned.
+func (r *resourceObject) AddDataE(data interface{}) error {
+	value, names, err := mapping.MapDataInOrderE(data)
 
-	for k, v := range value {
-		r.data[k] = v
+	if err != nil {
+		return err
 	}
+
+	r.addData(value, names)
+
+	return nil
+}
+
+func (r *resourceObject) addData(value mapping.PropertyMap, names []string) {
+	known := map[string]bool{}
+
+	for _, name := range r.dataNames {
+		known[name] = true
+	}
+
+	for _, name := range names {
+		if !known[name] {
+			r.dataNames = append(r.dataNames, name)
+		}
+
+		r.data[name] = value[name]
+	}
+}
+
+// orderedDataNames returns the names of all data properties. Properties assigned by AddData
+// keep their declaration order, followed by all other properties in sorted order.
+func (r *resourceObject) orderedDataNames() []string {
+	names := []string{}
+	known := map[string]bool{}
+
+	for _, name := range r.dataNames {
+		if _, ok := r.data[name]; ok && !known[name] {
+			known[name] = true
+			names = append(names, name)
+		}
+	}
+
+	remaining := []string{}
+
+	for name := range r.data {
+		if !known[name] {
+			remaining = append(remaining, name)
+		}
+	}
+
+	sort.Strings(remaining)
+
+	return append(names, remaining...)
 }
 
 // Links returns a mapping.NamedMap of assigned link relations.
@@ -64,6 +157,28 @@ func (r *resourceObject) EmbeddedResources() mapping.NamedMap {
 	return r.embedded.ToMap()
 }
 
+// LinkRelations returns assigned link relations in the order they were added.
+func (r *resourceObject) LinkRelations() []LinkRelation {
+	relations := make([]LinkRelation, 0, len(r.linkNames))
+
+	for _, name := range r.linkNames {
+		relations = append(relations, r.links[name])
+	}
+
+	return relations
+}
+
+// ResourceRelations returns assigned resource relations in the order they were added.
+func (r *resourceObject) ResourceRelations() []ResourceRelation {
+	relations := make([]ResourceRelation, 0, len(r.embeddedNames))
+
+	for _, name := range r.embeddedNames {
+		relations = append(relations, r.embedded[name])
+	}
+
+	return relations
+}
+
 // ToMap converts ResourceObject to mapping.NamedMap.
 func (r *resourceObject) ToMap() mapping.NamedMap {
 	properties := mapping.PropertyMap{}
@@ -93,11 +208,27 @@ func (r *resourceObject) AddCurieLinks(linkObjects []*LinkObject) {
 }
 
 // AddLink adds a LinRelation to ResourceObject.
+// An already existing relation with the same full name, e.g. doc:doctors, is replaced.
+// Relations with the same name but another CURIE, e.g. doc:doctors and ex:doctors, are kept both.
+// Up to v0.6.0, relations were identified by name without CURIE prefix and replaced each other.
 func (r *resourceObject) AddLink(rel LinkRelation) {
-	r.links[rel.Name()] = rel
+	name := rel.FullName()
+
+	if _, ok := r.links[name]; !ok {
+		r.linkNames = append(r.linkNames, name)
+	}
+
+	r.links[name] = rel
 }
 
 // AddResource adds a ResourceRelation to ResourceObject.
+// An already existing relation with the same full name is replaced, the same way AddLink does.
 func (r *resourceObject) AddResource(rel ResourceRelation) {
-	r.embedded[rel.Name()] = rel
+	name := rel.FullName()
+
+	if _, ok := r.embedded[name]; !ok {
+		r.embeddedNames = append(r.embeddedNames, name)
+	}
+
+	r.embedded[name] = rel
 }
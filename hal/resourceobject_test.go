@@ -5,6 +5,7 @@
 package hal
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -124,6 +125,65 @@ func TestAddCurieLink(t *testing.T) {
 	}
 }
 
+func TestAddLinkWithCurie(t *testing.T) {
+	docCurie, _ := NewCurieLink("doc", "http://doc/{rel}")
+	exCurie, _ := NewCurieLink("ex", "http://ex/{rel}")
+	resourceObject := NewResourceObject()
+
+	for _, curieLink := range []*LinkObject{docCurie, exCurie, docCurie} {
+		relation, _ := NewLinkRelation("doctors")
+		relation.SetCurieLink(curieLink)
+		relation.SetLink(&LinkObject{Href: curieLink.Name})
+		resourceObject.AddLink(relation)
+	}
+
+	wanted := []string{"doc:doctors", "ex:doctors"}
+	relations := linkRelationsOf(resourceObject)
+
+	if count := len(relations); count != len(wanted) {
+		t.Fatalf("Relation count == %d, want %d", count, len(wanted))
+	}
+
+	for i, relation := range relations {
+		if name := relation.FullName(); name != wanted[i] {
+			t.Errorf("Relation name == %s, want %s", name, wanted[i])
+		}
+	}
+}
+
+// foreignResource implements Resource, but not RelationLister.
+type foreignResource struct {
+	Resource
+}
+
+func TestResourceWithoutRelationLister(t *testing.T) {
+	embedded, _ := NewResourceRelation("doctors")
+	embedded.SetResources([]Resource{NewResourceObject()})
+	next, _ := NewLinkRelation("next")
+	next.SetLink(&LinkObject{Href: "/doctors?page=2"})
+
+	inner := NewResourceObject()
+	inner.AddLink(next)
+	inner.AddResource(embedded)
+	inner.Data()["count"] = 1
+	resource := foreignResource{inner}
+
+	if _, ok := Resource(resource).(RelationLister); ok {
+		t.Fatalf("foreignResource should not implement RelationLister")
+	}
+
+	wanted, _ := NewEncoder().ToJSON(inner)
+	value, err := NewEncoder().ToJSON(resource)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	if string(value) != string(wanted) {
+		t.Errorf("JSON value == %s, want %s", value, wanted)
+	}
+}
+
 func TestAddData(t *testing.T) {
 	resource := NewResourceObject()
 	data := resource.Data()
@@ -176,3 +236,44 @@ func TestAddData(t *testing.T) {
 		t.Errorf("Expected key %s with value %s in data", "a", [1]string{"B"})
 	}
 }
+
+type failingPrice float64
+
+func (p failingPrice) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("no price")
+}
+
+func TestAddDataE(t *testing.T) {
+	type Line struct {
+		Price failingPrice `json:"price"`
+	}
+
+	type Order struct {
+		ID    int    `json:"id"`
+		Lines []Line `json:"lines"`
+	}
+
+	resource := NewResourceObject().(*resourceObject)
+	err := resource.AddDataE(Order{ID: 1, Lines: []Line{{1}}})
+	var fieldError *mapping.FieldError
+
+	if !errors.As(err, &fieldError) {
+		t.Fatalf("AddDataE error is %T, want %T", err, fieldError)
+	}
+
+	if fieldError.Path != "Order.Lines[0].Price" {
+		t.Errorf("Field path is %s, want %s", fieldError.Path, "Order.Lines[0].Price")
+	}
+
+	if count := len(resource.Data()); count != 0 {
+		t.Errorf("Data amount %d, want %d", count, 0)
+	}
+
+	if err := resource.AddDataE(Order{ID: 1}); err != nil {
+		t.Fatalf("AddDataE returns error: %s", err)
+	}
+
+	if count := len(resource.Data()); count != 2 {
+		t.Errorf("Data amount %d, want %d", count, 2)
+	}
+}
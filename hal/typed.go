@@ -0,0 +1,102 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"fmt"
+
+	"github.com/pmoule/go2hal/hal/mapping"
+)
+
+// TypedResource is a Resource keeping the type of its data.
+// Links and embedded resources are added the same way as for any other Resource.
+type TypedResource[T any] struct {
+	Resource
+	value T
+}
+
+// NewTyped creates a TypedResource from value the same way FromStruct does. Fields tagged as link
+// or embedded become link relations and embedded resources, all other fields are assigned as data.
+func NewTyped[T any](value T) (*TypedResource[T], error) {
+	resource, err := FromStruct(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedResource[T]{Resource: resource, value: value}, nil
+}
+
+// Value returns the typed data.
+func (r *TypedResource[T]) Value() T {
+	return r.value
+}
+
+// LinkRelations returns the link relations of the wrapped Resource in the order they were added.
+func (r *TypedResource[T]) LinkRelations() []LinkRelation {
+	return linkRelationsOf(r.Resource)
+}
+
+// ResourceRelations returns the resource relations of the wrapped Resource in the order they were added.
+func (r *TypedResource[T]) ResourceRelations() []ResourceRelation {
+	return resourceRelationsOf(r.Resource)
+}
+
+// orderedDataNames returns the names of all data properties in the order of the wrapped Resource.
+func (r *TypedResource[T]) orderedDataNames() []string {
+	return readDataNames(r.Resource)
+}
+
+// Typed converts a Resource to a TypedResource. The data of the Resource is unmarshaled into T
+// the same way mapping.Unmarshal does.
+func Typed[T any](resource Resource) (*TypedResource[T], error) {
+	if typed, ok := resource.(*TypedResource[T]); ok {
+		return typed, nil
+	}
+
+	var value T
+
+	if err := mapping.Unmarshal(resource.Data(), &value); err != nil {
+		return nil, err
+	}
+
+	return &TypedResource[T]{Resource: resource, value: value}, nil
+}
+
+// DecodeTyped decodes a HAL document into a TypedResource. Links and embedded resources
+// are available the same way Decoder provides them.
+func DecodeTyped[T any](data []byte) (*TypedResource[T], error) {
+	resource, err := NewDecoder().FromJSON(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Typed[T](resource)
+}
+
+// Embedded returns the resources embedded by the relation with provided full name as TypedResource.
+// If there is no such relation, an empty slice is returned.
+func Embedded[T any](resource Resource, relationName string) ([]*TypedResource[T], error) {
+	result := []*TypedResource[T]{}
+
+	for _, relation := range resourceRelationsOf(resource) {
+		if relation.FullName() != relationName {
+			continue
+		}
+
+		for i, embedded := range relation.Resources() {
+			typed, err := Typed[T](embedded)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", relationName, i, err)
+			}
+
+			result = append(result, typed)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,180 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+type typedCompanion struct {
+	Name string `json:"name"`
+}
+
+type typedDoctor struct {
+	Name   string `json:"name"`
+	Number int    `json:"number"`
+}
+
+func TestNewTyped(t *testing.T) {
+	doctor := typedDoctor{Name: "The Doctor", Number: 1}
+	typed, err := NewTyped(doctor)
+
+	if err != nil {
+		t.Fatalf("NewTyped returns error: %s", err)
+	}
+
+	self, _ := NewLinkObject("/docwhoapi/doctors/1")
+	relation := NewSelfLinkRelation()
+	relation.SetLink(self)
+	typed.AddLink(relation)
+
+	companion, _ := NewTyped(typedCompanion{"Susan Foreman"})
+	companions, _ := NewResourceRelation("companions")
+	companions.SetResources([]Resource{companion})
+	typed.AddResource(companions)
+
+	if value := typed.Value(); value != doctor {
+		t.Errorf("Value is %v, want %v", value, doctor)
+	}
+
+	resource := NewResourceObject()
+	resource.AddLink(relation)
+	resource.AddData(doctor)
+	embedded := NewResourceObject()
+	embedded.AddData(typedCompanion{"Susan Foreman"})
+	companions, _ = NewResourceRelation("companions")
+	companions.SetResources([]Resource{embedded})
+	resource.AddResource(companions)
+
+	for _, encoder := range []Encoder{NewEncoder(), NewEncoder(WithOrderedProperties())} {
+		wanted, _ := encoder.ToJSON(resource)
+		value, err := encoder.ToJSON(typed)
+
+		if err != nil {
+			t.Fatalf("ToJSON returns error: %s", err)
+		}
+
+		if string(value) != string(wanted) {
+			t.Errorf("JSON value == %s, want %s", value, wanted)
+		}
+	}
+}
+
+func TestDecodeTyped(t *testing.T) {
+	document := `{
+		"_links": {"self": {"href": "/docwhoapi/doctors/1"}},
+		"_embedded": {"companions": [{"name": "Susan Foreman"}, {"name": "Barbara Wright"}]},
+		"name": "The Doctor",
+		"number": 1
+	}`
+
+	typed, err := DecodeTyped[typedDoctor]([]byte(document))
+
+	if err != nil {
+		t.Fatalf("DecodeTyped returns error: %s", err)
+	}
+
+	wanted := typedDoctor{Name: "The Doctor", Number: 1}
+
+	if value := typed.Value(); value != wanted {
+		t.Errorf("Value is %v, want %v", value, wanted)
+	}
+
+	if link := typed.Links().Content[relationtype.Self].(*LinkObject); link.Href != "/docwhoapi/doctors/1" {
+		t.Errorf("Self link is %s, want %s", link.Href, "/docwhoapi/doctors/1")
+	}
+
+	companions, err := Embedded[typedCompanion](typed, "companions")
+
+	if err != nil {
+		t.Fatalf("Embedded returns error: %s", err)
+	}
+
+	names := []string{"Susan Foreman", "Barbara Wright"}
+
+	if len(companions) != len(names) {
+		t.Fatalf("Companion count %d, want %d", len(companions), len(names))
+	}
+
+	for i, companion := range companions {
+		if name := companion.Value().Name; name != names[i] {
+			t.Errorf("Companion name is %s, want %s", name, names[i])
+		}
+	}
+
+	if missing, _ := Embedded[typedCompanion](typed, "enemies"); len(missing) != 0 {
+		t.Errorf("Unknown relation should have no resources")
+	}
+
+	if _, err := DecodeTyped[typedDoctor]([]byte(`{"number": "one"}`)); err == nil {
+		t.Errorf("DecodeTyped should return an error for invalid data")
+	}
+
+	if _, err := Embedded[typedDoctor](typed, "companions"); err != nil {
+		t.Errorf("Embedded returns error: %s", err)
+	}
+}
+
+func TestNewTypedWithTaggedFields(t *testing.T) {
+	type taggedDoctor struct {
+		Name       string           `json:"name"`
+		Self       *LinkObject      `hal:"link,rel=self"`
+		Companions []typedCompanion `hal:"embedded,rel=companions"`
+	}
+
+	doctor := taggedDoctor{Name: "The Doctor", Self: &LinkObject{Href: "/docwhoapi/doctors/1"},
+		Companions: []typedCompanion{{"Susan Foreman"}}}
+	typed, err := NewTyped(doctor)
+
+	if err != nil {
+		t.Fatalf("NewTyped returns error: %s", err)
+	}
+
+	if link, _ := typed.Links().Content[relationtype.Self].(*LinkObject); link != doctor.Self {
+		t.Errorf("Self link is %v, want %v", link, doctor.Self)
+	}
+
+	if companions, _ := Embedded[typedCompanion](typed, "companions"); len(companions) != 1 {
+		t.Errorf("Companion count %d, want %d", len(companions), 1)
+	}
+
+	if _, ok := typed.Data()["Self"]; ok {
+		t.Errorf("Link field should not be assigned as data")
+	}
+}
+
+func TestNewTypedWithInvalidValues(t *testing.T) {
+	if _, err := NewTyped("The Doctor"); err == nil {
+		t.Errorf("NewTyped should return an error for a string")
+	}
+
+	if _, err := NewTyped(struct {
+		Self string `hal:"link,rel=self"`
+	}{"/docwhoapi/doctors/1"}); err == nil {
+		t.Errorf("NewTyped should return an error for a link field of type string")
+	}
+}
+
+func TestTyped(t *testing.T) {
+	typed, _ := NewTyped(typedCompanion{"Susan Foreman"})
+
+	if result, _ := Typed[typedCompanion](typed); result != typed {
+		t.Errorf("Typed should return an already typed resource")
+	}
+
+	resource := NewResourceObject()
+	resource.Data()["name"] = "Barbara Wright"
+	result, err := Typed[*typedCompanion](resource)
+
+	if err != nil {
+		t.Fatalf("Typed returns error: %s", err)
+	}
+
+	if name := result.Value().Name; name != "Barbara Wright" {
+		t.Errorf("Name is %s, want %s", name, "Barbara Wright")
+	}
+}
@@ -0,0 +1,7 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+// Package uritemplate provides parsing and expansion of URI Templates
+// as specified in https://tools.ietf.org/html/rfc6570 up to level 4.
+package uritemplate
@@ -0,0 +1,588 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package uritemplate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError describes a malformed URI Template.
+type ParseError struct {
+	Template string
+	Offset   int
+	Message  string
+}
+
+// Error returns a description of the malformed part of the URI Template.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid URI template %q at offset %d: %s", e.Template, e.Offset, e.Message)
+}
+
+// operator describes the expansion behaviour of an expression type.
+// See https://tools.ietf.org/html/rfc6570#appendix-A.
+type operator struct {
+	first         string
+	separator     string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var operators = map[byte]operator{
+	'+': {first: "", separator: ",", allowReserved: true},
+	'#': {first: "#", separator: ",", allowReserved: true},
+	'.': {first: ".", separator: "."},
+	'/': {first: "/", separator: "/"},
+	';': {first: ";", separator: ";", named: true},
+	'?': {first: "?", separator: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", separator: "&", named: true, ifEmpty: "="},
+}
+
+var simpleOperator = operator{first: "", separator: ","}
+
+type varSpec struct {
+	name    string
+	explode bool
+	prefix  int
+}
+
+type expression struct {
+	operator operator
+	varSpecs []varSpec
+}
+
+type part struct {
+	literal    string
+	expression *expression
+}
+
+// Template is a parsed URI Template.
+type Template struct {
+	raw   string
+	parts []part
+}
+
+// Parse parses a URI Template. A ParseError is returned for malformed templates.
+func Parse(template string) (*Template, error) {
+	t := &Template{raw: template}
+	offset := 0
+
+	for offset < len(template) {
+		start := strings.IndexAny(template[offset:], "{}")
+
+		if start < 0 {
+			start = len(template)
+		} else {
+			start += offset
+		}
+
+		if start > offset {
+			literal := template[offset:start]
+
+			if index, message := checkLiteral(literal); index >= 0 {
+				return nil, &ParseError{Template: template, Offset: offset + index, Message: message}
+			}
+
+			t.parts = append(t.parts, part{literal: literal})
+		}
+
+		if start == len(template) {
+			break
+		}
+
+		if template[start] == '}' {
+			return nil, &ParseError{Template: template, Offset: start, Message: "unexpected '}'"}
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+
+		if end < 0 {
+			return nil, &ParseError{Template: template, Offset: start, Message: "unclosed expression"}
+		}
+
+		end += start
+		expr, err := parseExpression(template[start+1 : end])
+
+		if err != nil {
+			err.Template = template
+			err.Offset += start + 1
+
+			return nil, err
+		}
+
+		t.parts = append(t.parts, part{expression: expr})
+		offset = end + 1
+	}
+
+	return t, nil
+}
+
+// Expand parses a URI Template and expands it with provided values.
+func Expand(template string, values map[string]interface{}) (string, error) {
+	t, err := Parse(template)
+
+	if err != nil {
+		return "", err
+	}
+
+	return t.Expand(values)
+}
+
+// String returns the raw URI Template.
+func (t *Template) String() string {
+	return t.raw
+}
+
+// Variables returns the names of all variables in order of their first occurrence.
+func (t *Template) Variables() []string {
+	names := []string{}
+	known := map[string]bool{}
+
+	for _, p := range t.parts {
+		if p.expression == nil {
+			continue
+		}
+
+		for _, spec := range p.expression.varSpecs {
+			if !known[spec.name] {
+				known[spec.name] = true
+				names = append(names, spec.name)
+			}
+		}
+	}
+
+	return names
+}
+
+// Expand expands the URI Template with provided values.
+//
+// Supported values are strings, booleans and numbers, slices and arrays of them
+// as list values and maps of them as associative array values. Map keys are expanded
+// in sorted order. Missing variables, nil values, empty lists and empty maps are undefined
+// and therefore skipped.
+func (t *Template) Expand(values map[string]interface{}) (string, error) {
+	var builder strings.Builder
+
+	for _, p := range t.parts {
+		if p.expression == nil {
+			builder.WriteString(encode(p.literal, true))
+			continue
+		}
+
+		if err := p.expression.expand(&builder, values); err != nil {
+			return "", err
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func parseExpression(content string) (*expression, *ParseError) {
+	if content == "" {
+		return nil, &ParseError{Message: "empty expression"}
+	}
+
+	expr := &expression{operator: simpleOperator}
+	offset := 0
+
+	if op, ok := operators[content[0]]; ok {
+		expr.operator = op
+		offset = 1
+	} else if strings.IndexByte("=,!@|", content[0]) >= 0 {
+		return nil, &ParseError{Message: fmt.Sprintf("reserved operator '%c'", content[0])}
+	}
+
+	for _, spec := range strings.Split(content[offset:], ",") {
+		parsed, err := parseVarSpec(spec)
+
+		if err != nil {
+			err.Offset += offset
+			return nil, err
+		}
+
+		expr.varSpecs = append(expr.varSpecs, parsed)
+		offset += len(spec) + 1
+	}
+
+	return expr, nil
+}
+
+func parseVarSpec(spec string) (varSpec, *ParseError) {
+	parsed := varSpec{name: spec}
+
+	if strings.HasSuffix(spec, "*") {
+		parsed.name = spec[:len(spec)-1]
+		parsed.explode = true
+	} else if index := strings.IndexByte(spec, ':'); index >= 0 {
+		parsed.name = spec[:index]
+		length := spec[index+1:]
+
+		if length == "" || len(length) > 4 || length[0] == '0' {
+			return parsed, &ParseError{Offset: index + 1, Message: fmt.Sprintf("invalid prefix length %q", length)}
+		}
+
+		for i := 0; i < len(length); i++ {
+			if !isDigit(length[i]) {
+				return parsed, &ParseError{Offset: index + 1 + i, Message: fmt.Sprintf("invalid prefix length %q", length)}
+			}
+
+			parsed.prefix = parsed.prefix*10 + int(length[i]-'0')
+		}
+	}
+
+	if index, message := checkVarName(parsed.name); index >= 0 {
+		return parsed, &ParseError{Offset: index, Message: message}
+	}
+
+	return parsed, nil
+}
+
+// checkVarName returns the offset of the first invalid character or -1 for a valid name.
+func checkVarName(name string) (int, string) {
+	if name == "" {
+		return 0, "empty variable name"
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+
+		switch {
+		case isAlpha(c) || isDigit(c) || c == '_':
+		case c == '%':
+			if !isPctEncoded(name, i) {
+				return i, "invalid percent-encoding in variable name"
+			}
+
+			i += 2
+		case c == '.':
+			if i == 0 || i == len(name)-1 || name[i-1] == '.' {
+				return i, "misplaced '.' in variable name"
+			}
+		default:
+			return i, fmt.Sprintf("invalid character %q in variable name", c)
+		}
+	}
+
+	return -1, ""
+}
+
+// checkLiteral returns the offset of the first invalid character or -1 for a valid literal.
+func checkLiteral(literal string) (int, string) {
+	if !utf8.ValidString(literal) {
+		return 0, "invalid UTF-8 encoding"
+	}
+
+	for i := 0; i < len(literal); i++ {
+		c := literal[i]
+
+		switch {
+		case c == '%':
+			if !isPctEncoded(literal, i) {
+				return i, "invalid percent-encoding"
+			}
+		case c <= ' ' || c == 0x7f || strings.IndexByte("\"'<>\\^`|", c) >= 0:
+			return i, fmt.Sprintf("invalid character %q", c)
+		}
+	}
+
+	return -1, ""
+}
+
+func (e *expression) expand(builder *strings.Builder, values map[string]interface{}) error {
+	first := true
+
+	for _, spec := range e.varSpecs {
+		v, err := toValue(values[spec.name])
+
+		if err != nil {
+			return fmt.Errorf("variable %q: %w", spec.name, err)
+		}
+
+		if v.undefined() {
+			continue
+		}
+
+		if first {
+			builder.WriteString(e.operator.first)
+			first = false
+		} else {
+			builder.WriteString(e.operator.separator)
+		}
+
+		if err := e.expandValue(builder, spec, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *expression) expandValue(builder *strings.Builder, spec varSpec, v value) error {
+	op := e.operator
+
+	if v.kind == stringValue {
+		if op.named {
+			builder.WriteString(spec.name)
+
+			if v.str == "" {
+				builder.WriteString(op.ifEmpty)
+				return nil
+			}
+
+			builder.WriteString("=")
+		}
+
+		s := v.str
+
+		if spec.prefix > 0 {
+			s = truncate(s, spec.prefix)
+		}
+
+		builder.WriteString(encode(s, op.allowReserved))
+
+		return nil
+	}
+
+	if spec.prefix > 0 {
+		return fmt.Errorf("variable %q: prefix modifier is not applicable to composite values", spec.name)
+	}
+
+	if !spec.explode {
+		if op.named {
+			builder.WriteString(spec.name)
+			builder.WriteString("=")
+		}
+
+		items := []string{}
+
+		for i, item := range v.items {
+			if v.kind == mapValue {
+				items = append(items, encode(v.keys[i], op.allowReserved))
+			}
+
+			items = append(items, encode(item, op.allowReserved))
+		}
+
+		builder.WriteString(strings.Join(items, ","))
+
+		return nil
+	}
+
+	items := []string{}
+
+	for i, item := range v.items {
+		name := spec.name
+
+		if v.kind == mapValue {
+			name = encode(v.keys[i], op.allowReserved)
+		} else if !op.named {
+			items = append(items, encode(item, op.allowReserved))
+			continue
+		}
+
+		if op.named && item == "" {
+			items = append(items, name+op.ifEmpty)
+		} else {
+			items = append(items, name+"="+encode(item, op.allowReserved))
+		}
+	}
+
+	builder.WriteString(strings.Join(items, op.separator))
+
+	return nil
+}
+
+type valueKind int
+
+const (
+	undefinedValue valueKind = iota
+	stringValue
+	listValue
+	mapValue
+)
+
+type value struct {
+	kind  valueKind
+	str   string
+	keys  []string
+	items []string
+}
+
+func (v value) undefined() bool {
+	return v.kind == undefinedValue || (v.kind != stringValue && len(v.items) == 0)
+}
+
+// toValue converts a variable value to a string, list or associative array value.
+func toValue(data interface{}) (value, error) {
+	if data == nil {
+		return value{}, nil
+	}
+
+	v := reflect.ValueOf(data)
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return value{}, nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return value{}, nil
+		}
+
+		result := value{kind: listValue}
+
+		for i := 0; i < v.Len(); i++ {
+			item, err := toString(v.Index(i))
+
+			if err != nil {
+				return value{}, err
+			}
+
+			result.items = append(result.items, item)
+		}
+
+		return result, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return value{}, nil
+		}
+
+		result := value{kind: mapValue}
+		entries := map[string]string{}
+
+		for _, key := range v.MapKeys() {
+			k, err := toString(key)
+
+			if err != nil {
+				return value{}, err
+			}
+
+			item, err := toString(v.MapIndex(key))
+
+			if err != nil {
+				return value{}, err
+			}
+
+			result.keys = append(result.keys, k)
+			entries[k] = item
+		}
+
+		sort.Strings(result.keys)
+
+		for _, key := range result.keys {
+			result.items = append(result.items, entries[key])
+		}
+
+		return result, nil
+	}
+
+	s, err := toString(v)
+
+	if err != nil {
+		return value{}, err
+	}
+
+	return value{kind: stringValue, str: s}, nil
+}
+
+func toString(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), nil
+	}
+
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String(), nil
+	}
+
+	return "", fmt.Errorf("unsupported value of type %s", v.Type())
+}
+
+// truncate returns the first length characters of s.
+func truncate(s string, length int) string {
+	count := 0
+
+	for i := range s {
+		if count == length {
+			return s[:i]
+		}
+
+		count++
+	}
+
+	return s
+}
+
+const upperHex = "0123456789ABCDEF"
+
+// encode percent-encodes all characters not being unreserved. If allowReserved is set,
+// reserved characters and existing percent-encoded triplets are kept as well.
+func encode(s string, allowReserved bool) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case isUnreserved(c):
+			builder.WriteByte(c)
+		case allowReserved && isReserved(c):
+			builder.WriteByte(c)
+		case allowReserved && c == '%' && isPctEncoded(s, i):
+			builder.WriteString(s[i : i+3])
+			i += 2
+		default:
+			builder.WriteByte('%')
+			builder.WriteByte(upperHex[c>>4])
+			builder.WriteByte(upperHex[c&0x0f])
+		}
+	}
+
+	return builder.String()
+}
+
+func isPctEncoded(s string, index int) bool {
+	return index+2 < len(s) && isHex(s[index+1]) && isHex(s[index+2])
+}
+
+func isUnreserved(c byte) bool {
+	return isAlpha(c) || isDigit(c) || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isReserved(c byte) bool {
+	return strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,267 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package uritemplate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// values of https://tools.ietf.org/html/rfc6570#section-3.2
+var testValues = map[string]interface{}{
+	"count":      []string{"one", "two", "three"},
+	"dom":        []string{"example", "com"},
+	"dub":        "me/too",
+	"hello":      "Hello World!",
+	"half":       "50%",
+	"var":        "value",
+	"who":        "fred",
+	"base":       "http://example.com/home/",
+	"path":       "/foo/bar",
+	"list":       []string{"red", "green", "blue"},
+	"keys":       map[string]string{"semi": ";", "dot": ".", "comma": ","},
+	"v":          6,
+	"x":          1024,
+	"y":          768,
+	"empty":      "",
+	"empty_keys": map[string]string{},
+	"undef":      nil,
+}
+
+// Associative arrays are expanded in sorted key order. Expected values with keys
+// differ from the RFC examples in order only.
+var expansionTests = []struct {
+	template string
+	want     string
+}{
+	// level 1
+	{"{var}", "value"},
+	{"{hello}", "Hello%20World%21"},
+	// simple string expansion
+	{"{half}", "50%25"},
+	{"O{empty}X", "OX"},
+	{"O{undef}X", "OX"},
+	{"{x,y}", "1024,768"},
+	{"{x,hello,y}", "1024,Hello%20World%21,768"},
+	{"?{x,empty}", "?1024,"},
+	{"?{x,undef}", "?1024"},
+	{"?{undef,y}", "?768"},
+	{"{var:3}", "val"},
+	{"{var:30}", "value"},
+	{"{list}", "red,green,blue"},
+	{"{list*}", "red,green,blue"},
+	{"{keys}", "comma,%2C,dot,.,semi,%3B"},
+	{"{keys*}", "comma=%2C,dot=.,semi=%3B"},
+	// reserved expansion
+	{"{+var}", "value"},
+	{"{+hello}", "Hello%20World!"},
+	{"{+half}", "50%25"},
+	{"{base}index", "http%3A%2F%2Fexample.com%2Fhome%2Findex"},
+	{"{+base}index", "http://example.com/home/index"},
+	{"O{+empty}X", "OX"},
+	{"O{+undef}X", "OX"},
+	{"{+path}/here", "/foo/bar/here"},
+	{"here?ref={+path}", "here?ref=/foo/bar"},
+	{"up{+path}{var}/here", "up/foo/barvalue/here"},
+	{"{+x,hello,y}", "1024,Hello%20World!,768"},
+	{"{+path,x}/here", "/foo/bar,1024/here"},
+	{"{+path:6}/here", "/foo/b/here"},
+	{"{+list}", "red,green,blue"},
+	{"{+list*}", "red,green,blue"},
+	{"{+keys}", "comma,,,dot,.,semi,;"},
+	{"{+keys*}", "comma=,,dot=.,semi=;"},
+	// fragment expansion
+	{"{#var}", "#value"},
+	{"{#hello}", "#Hello%20World!"},
+	{"{#half}", "#50%25"},
+	{"foo{#empty}", "foo#"},
+	{"foo{#undef}", "foo"},
+	{"{#x,hello,y}", "#1024,Hello%20World!,768"},
+	{"{#path,x}/here", "#/foo/bar,1024/here"},
+	{"{#path:6}/here", "#/foo/b/here"},
+	{"{#list}", "#red,green,blue"},
+	{"{#list*}", "#red,green,blue"},
+	{"{#keys}", "#comma,,,dot,.,semi,;"},
+	{"{#keys*}", "#comma=,,dot=.,semi=;"},
+	// label expansion
+	{"{.who}", ".fred"},
+	{"{.who,who}", ".fred.fred"},
+	{"{.half,who}", ".50%25.fred"},
+	{"www{.dom*}", "www.example.com"},
+	{"X{.var}", "X.value"},
+	{"X{.empty}", "X."},
+	{"X{.undef}", "X"},
+	{"X{.var:3}", "X.val"},
+	{"X{.list}", "X.red,green,blue"},
+	{"X{.list*}", "X.red.green.blue"},
+	{"X{.keys}", "X.comma,%2C,dot,.,semi,%3B"},
+	{"X{.keys*}", "X.comma=%2C.dot=..semi=%3B"},
+	{"X{.empty_keys}", "X"},
+	{"X{.empty_keys*}", "X"},
+	// path segment expansion
+	{"{/who}", "/fred"},
+	{"{/who,who}", "/fred/fred"},
+	{"{/half,who}", "/50%25/fred"},
+	{"{/who,dub}", "/fred/me%2Ftoo"},
+	{"{/var}", "/value"},
+	{"{/var,empty}", "/value/"},
+	{"{/var,undef}", "/value"},
+	{"{/var,x}/here", "/value/1024/here"},
+	{"{/var:1,var}", "/v/value"},
+	{"{/list}", "/red,green,blue"},
+	{"{/list*}", "/red/green/blue"},
+	{"{/list*,path:4}", "/red/green/blue/%2Ffoo"},
+	{"{/keys}", "/comma,%2C,dot,.,semi,%3B"},
+	{"{/keys*}", "/comma=%2C/dot=./semi=%3B"},
+	// path-style parameter expansion
+	{"{;who}", ";who=fred"},
+	{"{;half}", ";half=50%25"},
+	{"{;empty}", ";empty"},
+	{"{;v,empty,who}", ";v=6;empty;who=fred"},
+	{"{;v,bar,who}", ";v=6;who=fred"},
+	{"{;x,y}", ";x=1024;y=768"},
+	{"{;x,y,empty}", ";x=1024;y=768;empty"},
+	{"{;x,y,undef}", ";x=1024;y=768"},
+	{"{;hello:5}", ";hello=Hello"},
+	{"{;list}", ";list=red,green,blue"},
+	{"{;list*}", ";list=red;list=green;list=blue"},
+	{"{;keys}", ";keys=comma,%2C,dot,.,semi,%3B"},
+	{"{;keys*}", ";comma=%2C;dot=.;semi=%3B"},
+	// form-style query expansion
+	{"{?who}", "?who=fred"},
+	{"{?half}", "?half=50%25"},
+	{"{?x,y}", "?x=1024&y=768"},
+	{"{?x,y,empty}", "?x=1024&y=768&empty="},
+	{"{?x,y,undef}", "?x=1024&y=768"},
+	{"{?var:3}", "?var=val"},
+	{"{?list}", "?list=red,green,blue"},
+	{"{?list*}", "?list=red&list=green&list=blue"},
+	{"{?keys}", "?keys=comma,%2C,dot,.,semi,%3B"},
+	{"{?keys*}", "?comma=%2C&dot=.&semi=%3B"},
+	// form-style query continuation
+	{"{&who}", "&who=fred"},
+	{"{&half}", "&half=50%25"},
+	{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+	{"{&x,y,empty}", "&x=1024&y=768&empty="},
+	{"{&var:3}", "&var=val"},
+	{"{&list}", "&list=red,green,blue"},
+	{"{&list*}", "&list=red&list=green&list=blue"},
+	{"{&keys}", "&keys=comma,%2C,dot,.,semi,%3B"},
+	{"{&keys*}", "&comma=%2C&dot=.&semi=%3B"},
+	// literals and unicode
+	{"/docwhoapi/doctors", "/docwhoapi/doctors"},
+	{"/café/{var}", "/caf%C3%A9/value"},
+	{"/%7Euser{/who}", "/%7Euser/fred"},
+}
+
+func TestExpand(t *testing.T) {
+	for _, test := range expansionTests {
+		result, err := Expand(test.template, testValues)
+
+		if err != nil {
+			t.Errorf("Expand(%q) returns error: %s", test.template, err)
+			continue
+		}
+
+		if result != test.want {
+			t.Errorf("Expand(%q) == %q, want %q", test.template, result, test.want)
+		}
+	}
+}
+
+func TestExpandWithUnicodePrefix(t *testing.T) {
+	result, err := Expand("{var:2}", map[string]interface{}{"var": "été"})
+
+	if err != nil {
+		t.Fatalf("Expand returns error: %s", err)
+	}
+
+	if want := "%C3%A9t"; result != want {
+		t.Errorf("Expand == %q, want %q", result, want)
+	}
+}
+
+func TestExpandWithInvalidValues(t *testing.T) {
+	tests := []struct {
+		template string
+		values   map[string]interface{}
+	}{
+		{"{list:3}", map[string]interface{}{"list": []string{"a"}}},
+		{"{keys:3}", map[string]interface{}{"keys": map[string]string{"a": "b"}}},
+		{"{list}", map[string]interface{}{"list": [][]string{{"a"}}}},
+		{"{var}", map[string]interface{}{"var": struct{}{}}},
+	}
+
+	for _, test := range tests {
+		if _, err := Expand(test.template, test.values); err == nil {
+			t.Errorf("Expand(%q) should return an error", test.template)
+		}
+	}
+}
+
+func TestParseWithInvalidTemplates(t *testing.T) {
+	templates := []string{
+		"{",
+		"}",
+		"{}",
+		"{var",
+		"var}",
+		"{ var}",
+		"{va r}",
+		"{var,}",
+		"{,var}",
+		"{var:0}",
+		"{var:10000}",
+		"{var:a}",
+		"{var:}",
+		"{var*:3}",
+		"{.var.}",
+		"{a..b}",
+		"{%zz}",
+		"{=var}",
+		"{!var}",
+		"{@var}",
+		"{|var}",
+		"{a{b}",
+		"/a b",
+		"/a%zz",
+		"/a<b>",
+		"/a\"b",
+	}
+
+	for _, template := range templates {
+		_, err := Parse(template)
+
+		if err == nil {
+			t.Errorf("Parse(%q) should return an error", template)
+			continue
+		}
+
+		var parseError *ParseError
+
+		if !errors.As(err, &parseError) {
+			t.Errorf("Parse(%q) error is %T, want %T", template, err, parseError)
+		}
+	}
+}
+
+func TestVariables(t *testing.T) {
+	template, err := Parse("/orders{/id}{?page,size}{&page,sort*}{#var:3}")
+
+	if err != nil {
+		t.Fatalf("Parse returns error: %s", err)
+	}
+
+	wanted := []string{"id", "page", "size", "sort", "var"}
+
+	if variables := template.Variables(); !reflect.DeepEqual(variables, wanted) {
+		t.Errorf("Variables == %v, want %v", variables, wanted)
+	}
+
+	if template.String() != "/orders{/id}{?page,size}{&page,sort*}{#var:3}" {
+		t.Errorf("String == %q", template.String())
+	}
+}
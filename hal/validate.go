@@ -0,0 +1,406 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+	"github.com/pmoule/go2hal/hal/uritemplate"
+)
+
+// Causes of a LinkError. Use errors.Is to check for them.
+var (
+	ErrMissingHref        = errors.New("href is required")
+	ErrInvalidURIRef      = errors.New("not a URI reference")
+	ErrInvalidURI         = errors.New("not an absolute URI")
+	ErrInvalidLanguageTag = errors.New("not a BCP 47 language tag")
+	ErrInvalidMediaType   = errors.New("not a media type")
+	ErrInvalidCurie       = errors.New("invalid CURIE")
+)
+
+// LinkError describes an invalid property of a LinkObject.
+type LinkError struct {
+	// Property is the JSON name of the invalid property, e.g. hreflang.
+	Property string
+	Value    string
+	Err      error
+}
+
+// Error returns property, value and cause.
+func (e *LinkError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Property, e.Value, e.Err)
+}
+
+// Unwrap returns the cause.
+func (e *LinkError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError describes a problem of a HAL document at the location of a JSON pointer.
+type ValidationError struct {
+	// Pointer is a JSON pointer (RFC 6901), e.g. /_embedded/items/0/_links/self/href.
+	Pointer string
+	Err     error
+}
+
+// Error returns location and cause.
+func (e *ValidationError) Error() string {
+	return e.Pointer + ": " + e.Err.Error()
+}
+
+// Unwrap returns the cause.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors lists all problems found by Validate in document order.
+type ValidationErrors []*ValidationError
+
+// Error returns all problems, one per line.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns all problems, so errors.Is and errors.As check each of them.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// Validate checks the properties of a LinkObject as required by the HAL draft.
+// Href must be a URI reference or a URI Template if templated, HrefLang a BCP 47 language tag,
+// Type a media type, Profile a URI and Deprecation a URL.
+// All problems are returned as *LinkError joined by errors.Join.
+func (l *LinkObject) Validate() error {
+	errs := []error{}
+
+	for _, err := range l.validate() {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (l *LinkObject) validate() []*LinkError {
+	errs := []*LinkError{}
+
+	if err := validateHref(l.Href, l.Templated); err != nil {
+		errs = append(errs, &LinkError{Property: "href", Value: l.Href, Err: err})
+	}
+
+	if l.HrefLang != "" && !isLanguageTag(l.HrefLang) {
+		errs = append(errs, &LinkError{Property: "hreflang", Value: l.HrefLang, Err: ErrInvalidLanguageTag})
+	}
+
+	if l.Type != "" && !isMediaType(l.Type) {
+		errs = append(errs, &LinkError{Property: "type", Value: l.Type, Err: ErrInvalidMediaType})
+	}
+
+	if l.Profile != "" && !isAbsoluteURI(l.Profile) {
+		errs = append(errs, &LinkError{Property: "profile", Value: l.Profile, Err: ErrInvalidURI})
+	}
+
+	if l.Deprecation != "" && !isAbsoluteURI(l.Deprecation) {
+		errs = append(errs, &LinkError{Property: "deprecation", Value: l.Deprecation, Err: ErrInvalidURI})
+	}
+
+	return errs
+}
+
+// validateCurie checks the additional requirements of a CURIE link.
+func (l *LinkObject) validateCurie() []*LinkError {
+	errs := l.validate()
+
+	if l.Name == "" {
+		errs = append(errs, &LinkError{Property: "name", Err: fmt.Errorf("%w: name is required", ErrInvalidCurie)})
+	}
+
+	if !l.Templated {
+		errs = append(errs, &LinkError{Property: "templated", Value: "false", Err: fmt.Errorf("%w: href must be templated", ErrInvalidCurie)})
+	} else if template, err := uritemplate.Parse(l.Href); err == nil && !hasVariable(template, "rel") {
+		// a malformed template is reported by validate already
+		errs = append(errs, &LinkError{Property: "href", Value: l.Href, Err: fmt.Errorf("%w: href requires variable rel", ErrInvalidCurie)})
+	}
+
+	return errs
+}
+
+// Validate checks all links, CURIEs and embedded resources of a Resource, including the ones of
+// embedded resources. All problems are returned as ValidationErrors with the JSON pointer of the
+// invalid value, e.g. /_links/author/hreflang. If there is no problem, nil is returned.
+func Validate(resource Resource) error {
+	errs := validateResource(resource, "", false, nil)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// validateResource checks a resource and its embedded resources. If strict, compliance with the
+// HAL draft is checked as well, curies are the CURIE links defined by the enclosing resources.
+func validateResource(resource Resource, pointer string, strict bool, curies map[string]*LinkObject) ValidationErrors {
+	errs := ValidationErrors{}
+
+	if strict {
+		curies = resourceCuries(resource, curies)
+		errs = append(errs, checkResource(resource, pointer, curies)...)
+	}
+
+	for _, relation := range linkRelationsOf(resource) {
+		relationPointer := pointer + "/" + LinksProperty + "/" + escapePointer(relation.FullName())
+
+		for i, link := range relation.Links() {
+			linkPointer := relationPointer
+
+			if relation.IsLinkSet() {
+				linkPointer += "/" + strconv.Itoa(i)
+			}
+
+			if link == nil {
+				errs = append(errs, &ValidationError{Pointer: linkPointer, Err: errors.New("link must not be nil")})
+
+				continue
+			}
+
+			linkErrs := link.validate()
+
+			if relation.FullName() == relationtype.CURIES {
+				linkErrs = link.validateCurie()
+			}
+
+			for _, err := range linkErrs {
+				errs = append(errs, &ValidationError{Pointer: linkPointer + "/" + err.Property, Err: err})
+			}
+		}
+	}
+
+	for _, relation := range resourceRelationsOf(resource) {
+		relationPointer := pointer + "/" + EmbeddedProperty + "/" + escapePointer(relation.FullName())
+
+		for i, embedded := range relation.Resources() {
+			resourcePointer := relationPointer
+
+			if relation.IsResourceSet() {
+				resourcePointer += "/" + strconv.Itoa(i)
+			}
+
+			if embedded == nil {
+				errs = append(errs, &ValidationError{Pointer: resourcePointer, Err: errors.New("embedded resource must not be nil")})
+
+				continue
+			}
+
+			errs = append(errs, validateResource(embedded, resourcePointer, strict, curies)...)
+		}
+	}
+
+	return errs
+}
+
+// escapePointer escapes a reference token of a JSON pointer.
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// validateHref checks a href being a URI reference (RFC 3986) or a URI Template (RFC 6570).
+func validateHref(href string, templated bool) error {
+	if href == "" {
+		return ErrMissingHref
+	}
+
+	if templated {
+		if _, err := uritemplate.Parse(href); err != nil {
+			return err
+		}
+
+		// all expressions are removed, the literals remain
+		expanded, err := uritemplate.Expand(href, nil)
+
+		if err != nil {
+			return err
+		}
+
+		href = expanded
+	}
+
+	if !isURIReference(href) {
+		return ErrInvalidURIRef
+	}
+
+	return nil
+}
+
+// isURIReference checks a value being a URI reference consisting of allowed characters only.
+func isURIReference(value string) bool {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case c == '%':
+			if i+2 >= len(value) || !isHexDigit(value[i+1]) || !isHexDigit(value[i+2]) {
+				return false
+			}
+
+			i += 2
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("-._~:/?#[]@!$&'()*+,;=", c) >= 0:
+		default:
+			return false
+		}
+	}
+
+	_, err := url.Parse(value)
+
+	return err == nil
+}
+
+// isAbsoluteURI checks a value being a URI with scheme.
+func isAbsoluteURI(value string) bool {
+	if !isURIReference(value) {
+		return false
+	}
+
+	uri, _ := url.Parse(value)
+
+	return uri.Scheme != ""
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// isMediaType checks a value being a media type with optional parameters, e.g. text/html; charset=utf-8.
+func isMediaType(value string) bool {
+	mediaType, _, err := mime.ParseMediaType(value)
+
+	return err == nil && strings.Contains(mediaType, "/")
+}
+
+// irregularLanguageTags are grandfathered tags not matching the language tag syntax.
+var irregularLanguageTags = map[string]bool{
+	"en-gb-oed": true, "i-ami": true, "i-bnn": true, "i-default": true, "i-enochian": true,
+	"i-hak": true, "i-klingon": true, "i-lux": true, "i-mingo": true, "i-navajo": true,
+	"i-pwn": true, "i-tao": true, "i-tay": true, "i-tsu": true, "sgn-be-fr": true,
+	"sgn-be-nl": true, "sgn-ch-de": true,
+}
+
+// isLanguageTag checks a value being a well-formed BCP 47 language tag (RFC 5646).
+func isLanguageTag(value string) bool {
+	tag := strings.ToLower(value)
+
+	if irregularLanguageTags[tag] {
+		return true
+	}
+
+	subtags := strings.Split(tag, "-")
+
+	for _, subtag := range subtags {
+		if len(subtag) < 1 || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return false
+		}
+	}
+
+	if subtags[0] == "x" {
+		return len(subtags) > 1
+	}
+
+	// language
+	i := 1
+
+	switch language := subtags[0]; {
+	case !isAlphabetic(language) || len(language) < 2:
+		return false
+	case len(language) <= 3:
+		// extended language subtags
+		for j := 0; j < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlphabetic(subtags[i]); j++ {
+			i++
+		}
+	}
+
+	// script
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlphabetic(subtags[i]) {
+		i++
+	}
+
+	// region
+	if i < len(subtags) && ((len(subtags[i]) == 2 && isAlphabetic(subtags[i])) || (len(subtags[i]) == 3 && isNumeric(subtags[i]))) {
+		i++
+	}
+
+	// variants
+	for i < len(subtags) && (len(subtags[i]) >= 5 || (len(subtags[i]) == 4 && isNumeric(subtags[i][:1]))) {
+		i++
+	}
+
+	// extensions
+	for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+		i++
+		start := i
+
+		for i < len(subtags) && len(subtags[i]) >= 2 {
+			i++
+		}
+
+		if i == start {
+			return false
+		}
+	}
+
+	// private use
+	if i < len(subtags) && subtags[i] == "x" {
+		return i+1 < len(subtags)
+	}
+
+	return i == len(subtags)
+}
+
+func isAlphabetic(value string) bool {
+	for _, c := range value {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNumeric(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlphanumeric(value string) bool {
+	for _, c := range value {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,147 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLinkObjectValidate(t *testing.T) {
+	validLinks := []*LinkObject{
+		{Href: "/docwhoapi/doctors"},
+		{Href: "http://example.com/docwhoapi/doctors?page=1#top"},
+		{Href: "/docwhoapi/doctors{?page,size}", Templated: true},
+		{Href: "/docwhoapi/doctors/%C3%A9", HrefLang: "en-GB", Type: "application/hal+json; charset=utf-8"},
+		{Href: "/docwhoapi/doctors", HrefLang: "zh-Hant-TW", Profile: "http://example.com/profiles/doctor"},
+		{Href: "/docwhoapi/doctors", HrefLang: "de-CH-1996-x-private", Deprecation: "http://example.com/deprecated"},
+		{Href: "/docwhoapi/doctors", HrefLang: "i-klingon"},
+	}
+
+	for _, link := range validLinks {
+		if err := link.Validate(); err != nil {
+			t.Errorf("Validate(%+v) returns error: %s", link, err)
+		}
+	}
+
+	invalidLinks := []struct {
+		link     *LinkObject
+		property string
+		cause    error
+	}{
+		{&LinkObject{}, "href", ErrMissingHref},
+		{&LinkObject{Href: "/docwhoapi/doctor who"}, "href", ErrInvalidURIRef},
+		{&LinkObject{Href: "/docwhoapi/doctors/%zz"}, "href", ErrInvalidURIRef},
+		{&LinkObject{Href: "/docwhoapi/doctors{?page}"}, "href", ErrInvalidURIRef},
+		{&LinkObject{Href: "/docwhoapi/doctors", HrefLang: "123"}, "hreflang", ErrInvalidLanguageTag},
+		{&LinkObject{Href: "/docwhoapi/doctors", HrefLang: "en-a"}, "hreflang", ErrInvalidLanguageTag},
+		{&LinkObject{Href: "/docwhoapi/doctors", HrefLang: "en_GB"}, "hreflang", ErrInvalidLanguageTag},
+		{&LinkObject{Href: "/docwhoapi/doctors", Type: "json"}, "type", ErrInvalidMediaType},
+		{&LinkObject{Href: "/docwhoapi/doctors", Type: "application/"}, "type", ErrInvalidMediaType},
+		{&LinkObject{Href: "/docwhoapi/doctors", Profile: "/profiles/doctor"}, "profile", ErrInvalidURI},
+		{&LinkObject{Href: "/docwhoapi/doctors", Deprecation: "deprecated"}, "deprecation", ErrInvalidURI},
+	}
+
+	for _, test := range invalidLinks {
+		err := test.link.Validate()
+		linkError := &LinkError{}
+
+		if !errors.As(err, &linkError) {
+			t.Errorf("Validate(%+v) returns %v, want a *LinkError", test.link, err)
+
+			continue
+		}
+
+		if linkError.Property != test.property {
+			t.Errorf("Property is %s, want %s", linkError.Property, test.property)
+		}
+
+		if !errors.Is(err, test.cause) {
+			t.Errorf("Error is %v, want %v", err, test.cause)
+		}
+	}
+
+	if err := (&LinkObject{Href: "/docwhoapi/doctors{?page", Templated: true}).Validate(); err == nil {
+		t.Errorf("Validate should return an error for a malformed template")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	root := NewResourceObject()
+	curie, _ := NewCurieLink("doc", "http://example.com/docs/{rel}")
+	invalidCurie := &LinkObject{Href: "http://example.com/docs"}
+	root.AddCurieLinks([]*LinkObject{curie, invalidCurie})
+
+	self := NewSelfLinkRelation()
+	self.SetLink(&LinkObject{Href: "/docwhoapi/doctors", HrefLang: "123"})
+	root.AddLink(self)
+
+	companions, _ := NewLinkRelation("doc/companions")
+	companions.SetLinks([]*LinkObject{{Href: "/docwhoapi/companions"}, {Href: "/docwhoapi/companion list"}})
+	root.AddLink(companions)
+
+	doctor := NewResourceObject()
+	doctorSelf := NewSelfLinkRelation()
+	doctorSelf.SetLink(&LinkObject{Href: "/docwhoapi/doctors/1", Type: "json"})
+	doctor.AddLink(doctorSelf)
+	doctors, _ := NewResourceRelation("doctors")
+	doctors.SetResources([]Resource{NewResourceObject(), doctor})
+	root.AddResource(doctors)
+
+	err := Validate(root)
+	validationErrors := ValidationErrors{}
+
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("Error is %v, want ValidationErrors", err)
+	}
+
+	pointers := []string{
+		"/_links/curies/1/name",
+		"/_links/curies/1/templated",
+		"/_links/self/hreflang",
+		"/_links/doc~1companions/1/href",
+		"/_embedded/doctors/1/_links/self/type",
+	}
+
+	if len(validationErrors) != len(pointers) {
+		t.Fatalf("Error count %d, want %d: %s", len(validationErrors), len(pointers), err)
+	}
+
+	for i, pointer := range pointers {
+		if validationErrors[i].Pointer != pointer {
+			t.Errorf("Pointer is %s, want %s", validationErrors[i].Pointer, pointer)
+		}
+	}
+
+	if !errors.Is(err, ErrInvalidCurie) || !errors.Is(err, ErrInvalidMediaType) {
+		t.Errorf("Error %v should contain all causes", err)
+	}
+
+	if err := Validate(doctors.Resources()[0]); err != nil {
+		t.Errorf("Validate returns error: %s", err)
+	}
+}
+
+func TestValidateCurie(t *testing.T) {
+	tests := []struct {
+		href  string
+		valid bool
+	}{
+		{"http://example.com/docs/{rel}", true},
+		{"http://example.com/docs{/rel}", true},
+		{"http://example.com/docs/{+rel}", true},
+		{"http://example.com/docs{#rel}", true},
+		{"http://example.com/docs/", false},
+		{"http://example.com/docs/{relation}", false},
+	}
+
+	for _, test := range tests {
+		curie := &LinkObject{Href: test.href, Templated: true, Name: "doc"}
+
+		if errs := curie.validateCurie(); (len(errs) == 0) != test.valid {
+			t.Errorf("CURIE errors for %s are %v, want valid %t", test.href, errs, test.valid)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+// XMLDecoder to decode a HAL+XML document into a Resource.
+type XMLDecoder interface {
+	FromXML(data []byte) (Resource, error)
+}
+
+type xmlDecoder struct {
+	options decoderOptions
+}
+
+// NewXMLDecoder creates a HAL+XML decoder. WithStrictDecoding is supported.
+func NewXMLDecoder(options ...DecoderOption) XMLDecoder {
+	decoder := new(xmlDecoder)
+
+	for _, option := range options {
+		option(&decoder.options)
+	}
+
+	return decoder
+}
+
+// FromXML creates a Resource from provided HAL+XML document written the way XMLEncoder does.
+//
+// The href attribute of a resource element becomes its self link. Link and resource elements with
+// rel attribute become link relations and embedded resources. A relation with more than one link
+// or embedded resource is structured in an array, CURIE links always are. So is a relation whose
+// element has an array="true" attribute.
+// All other elements are assigned as data. Elements without type attribute are strings, or objects
+// if they have child elements. Repeated child elements become arrays.
+func (dec *xmlDecoder) FromXML(data []byte) (Resource, error) {
+	root, err := parseXMLElement(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if root.name != "resource" {
+		return nil, fmt.Errorf("root element is %s, want resource", root.name)
+	}
+
+	node, err := xmlResourceNode(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+
+	if err := node.writeJSON(buffer); err != nil {
+		return nil, err
+	}
+
+	resource, err := decodeResource(buffer.Bytes(), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dec.options.strict {
+		if err := CheckCompliance(resource); err != nil {
+			return nil, err
+		}
+	}
+
+	return resource, nil
+}
+
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+func (e *xmlElement) attr(name string) (string, bool) {
+	for _, attr := range e.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// parseXMLElement parses an XML document into its root element. Comments, processing
+// instructions and directives are ignored.
+func parseXMLElement(data []byte) (*xmlElement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	stack := []*xmlElement{}
+	var root *xmlElement
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch value := token.(type) {
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				return nil, errors.New("unexpected element after root element")
+			}
+
+			element := &xmlElement{name: value.Name.Local, attrs: value.Attr}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+			} else {
+				root = element
+			}
+
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(value)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("missing resource element")
+	}
+
+	return root, nil
+}
+
+// xmlResourceNode converts a resource element into the JSON form of the resource.
+func xmlResourceNode(element *xmlElement) (*jsonNode, error) {
+	links := &jsonNode{kind: jsonObject}
+	embedded := &jsonNode{kind: jsonObject}
+	data := &jsonNode{kind: jsonObject}
+	repeated := map[*jsonNode]bool{}
+
+	if href, ok := element.attr("href"); ok {
+		appendMember(links, "self", repeated, &jsonNode{kind: jsonObject, names: []string{"href"}, members: []*jsonNode{{kind: jsonString, text: href}}})
+	}
+
+	for _, child := range element.children {
+		rel, isRelation := child.attr("rel")
+
+		switch {
+		case child.name == "link" && isRelation:
+			appendMember(links, rel, repeated, xmlLinkNode(child))
+
+			if isArrayElement(child) {
+				toArray(links.member(rel), repeated)
+			}
+		case child.name == "resource" && isRelation:
+			resource, err := xmlResourceNode(child)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", rel, err)
+			}
+
+			appendMember(embedded, rel, repeated, resource)
+
+			if isArrayElement(child) {
+				toArray(embedded.member(rel), repeated)
+			}
+		default:
+			value, err := xmlDataNode(child)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", child.name, err)
+			}
+
+			data.names = append(data.names, child.name)
+			data.members = append(data.members, value)
+		}
+	}
+
+	node := &jsonNode{kind: jsonObject}
+
+	if len(links.members) > 0 {
+		node.names = append(node.names, LinksProperty)
+		node.members = append(node.members, links)
+	}
+
+	if len(embedded.members) > 0 {
+		node.names = append(node.names, EmbeddedProperty)
+		node.members = append(node.members, embedded)
+	}
+
+	// CURIE links are always an array
+	if curies := links.member(relationtype.CURIES); curies != nil {
+		toArray(curies, repeated)
+	}
+
+	for i, name := range data.names {
+		if name == LinksProperty || name == EmbeddedProperty {
+			return nil, fmt.Errorf("%s: %w", name, ErrReservedProperty)
+		}
+
+		node.names = append(node.names, name)
+		node.members = append(node.members, data.members[i])
+	}
+
+	return node, nil
+}
+
+// appendMember adds a value to an object. Values of repeated names are structured in an array,
+// which is registered in repeated.
+func appendMember(object *jsonNode, name string, repeated map[*jsonNode]bool, value *jsonNode) {
+	existing := object.member(name)
+
+	switch {
+	case existing == nil:
+		object.names = append(object.names, name)
+		object.members = append(object.members, value)
+	case repeated[existing]:
+		existing.members = append(existing.members, value)
+	default:
+		toArray(existing, repeated)
+		existing.members = append(existing.members, value)
+	}
+}
+
+// toArray structures a value not being registered in repeated in an array and registers it.
+func toArray(value *jsonNode, repeated map[*jsonNode]bool) {
+	if repeated[value] {
+		return
+	}
+
+	first := *value
+	*value = jsonNode{kind: jsonArray, members: []*jsonNode{&first}}
+	repeated[value] = true
+}
+
+// isArrayElement checks a link or resource element being marked as element of an array.
+func isArrayElement(element *xmlElement) bool {
+	array, _ := element.attr("array")
+
+	return array == "true"
+}
+
+// xmlLinkNode converts the attributes of a link element, except rel and array, into a Link Object.
+func xmlLinkNode(element *xmlElement) *jsonNode {
+	link := &jsonNode{kind: jsonObject}
+
+	for _, attr := range element.attrs {
+		if attr.Name.Space != "" || attr.Name.Local == "rel" || attr.Name.Local == "array" {
+			continue
+		}
+
+		value := &jsonNode{kind: jsonString, text: attr.Value}
+
+		if attr.Name.Local == "templated" {
+			value.kind = jsonBoolean
+			value.text = fmt.Sprint(attr.Value == "true")
+		}
+
+		link.names = append(link.names, attr.Name.Local)
+		link.members = append(link.members, value)
+	}
+
+	return link
+}
+
+// xmlWhitespace are the whitespace characters of XML surrounding number and boolean values.
+const xmlWhitespace = " \t\r\n"
+
+// parseJSONNumber parses a text being a single number as defined by the JSON grammar.
+func parseJSONNumber(text string) (json.Number, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+
+	if err != nil {
+		return "", err
+	}
+
+	number, ok := token.(json.Number)
+
+	if !ok {
+		return "", errors.New("not a number")
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", errors.New("unexpected data after number")
+	}
+
+	return number, nil
+}
+
+// xmlDataNode converts a data element into a JSON value. The type attribute defines the kind of
+// value, elements without type attribute are strings or objects. Whitespace surrounding numbers
+// and booleans is ignored.
+func xmlDataNode(element *xmlElement) (*jsonNode, error) {
+	kind, _ := element.attr("type")
+	text := element.text.String()
+
+	switch kind {
+	case "number":
+		number, err := parseJSONNumber(strings.Trim(text, xmlWhitespace))
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", text)
+		}
+
+		return &jsonNode{kind: jsonNumber, text: number.String()}, nil
+	case "boolean":
+		value := strings.Trim(text, xmlWhitespace)
+
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("invalid boolean %q", text)
+		}
+
+		return &jsonNode{kind: jsonBoolean, text: value}, nil
+	case "null":
+		return &jsonNode{kind: jsonNull}, nil
+	case "array":
+		node := &jsonNode{kind: jsonArray}
+
+		for _, child := range element.children {
+			item, err := xmlDataNode(child)
+
+			if err != nil {
+				return nil, err
+			}
+
+			node.members = append(node.members, item)
+		}
+
+		return node, nil
+	case "", "object", "string":
+		if len(element.children) == 0 && kind != "object" {
+			return &jsonNode{kind: jsonString, text: text}, nil
+		}
+
+		node := &jsonNode{kind: jsonObject}
+		repeated := map[*jsonNode]bool{}
+
+		for _, child := range element.children {
+			value, err := xmlDataNode(child)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", child.name, err)
+			}
+
+			appendMember(node, child.name, repeated, value)
+		}
+
+		return node, nil
+	}
+
+	return nil, fmt.Errorf("unknown type %q", kind)
+}
@@ -0,0 +1,201 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+const xmlDecoderTestDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- doctors of the Doctor -->
+<resource href="/docwhoapi/doctors">
+	<link rel="curies" href="http://example.com/docs/relations/{rel}" templated="true" name="doc"/>
+	<link rel="doc:companions" href="/docwhoapi/companions" title="Companions"/>
+	<link rel="alternate" href="/docwhoapi/doctors.xml" type="application/xml"/>
+	<link rel="alternate" href="/docwhoapi/doctors.json" type="application/json"/>
+	<resource rel="doc:doctors" href="/docwhoapi/doctors/1">
+		<name>William Hartnell</name>
+	</resource>
+	<resource rel="doc:doctors" href="/docwhoapi/doctors/2">
+		<name>Patrick Troughton</name>
+	</resource>
+	<doctorCount type="number">12</doctorCount>
+	<regular type="boolean">true</regular>
+	<content>All actors &amp; doctors.</content>
+	<details>
+		<from>1963</from>
+		<until type="null"/>
+		<actor>William Hartnell</actor>
+		<actor>Patrick Troughton</actor>
+	</details>
+	<seasons type="array"><item type="number">1</item><item>2</item></seasons>
+	<empty type="object"/>
+</resource>`
+
+func TestXMLDecoder(t *testing.T) {
+	resource, err := NewXMLDecoder().FromXML([]byte(xmlDecoderTestDocument))
+
+	if err != nil {
+		t.Fatalf("FromXML returns error: %s", err)
+	}
+
+	links := resource.Links().Content
+
+	if href := links[relationtype.Self].(*LinkObject).Href; href != "/docwhoapi/doctors" {
+		t.Errorf("Self href is %s, want %s", href, "/docwhoapi/doctors")
+	}
+
+	if curies, ok := links[relationtype.CURIES].([]*LinkObject); !ok || len(curies) != 1 || !curies[0].Templated {
+		t.Errorf("CURIE links are %v, want one templated link", links[relationtype.CURIES])
+	}
+
+	if companions, ok := links["doc:companions"].(*LinkObject); !ok || companions.Title != "Companions" {
+		t.Errorf("Companions link is %v, want link with title Companions", links["doc:companions"])
+	}
+
+	if alternates, ok := links["alternate"].([]*LinkObject); !ok || len(alternates) != 2 {
+		t.Errorf("Alternate links are %v, want 2 links", links["alternate"])
+	}
+
+	if doctors := resourceRelationsOf(resource); len(doctors) != 1 || len(doctors[0].Resources()) != 2 {
+		t.Errorf("Embedded doctors are %v, want 2 resources", doctors)
+	}
+
+	wanted := map[string]interface{}{
+		"doctorCount": json.Number("12"),
+		"regular":     true,
+		"content":     "All actors & doctors.",
+		"details": map[string]interface{}{
+			"from":  "1963",
+			"until": nil,
+			"actor": []interface{}{"William Hartnell", "Patrick Troughton"},
+		},
+		"seasons": []interface{}{json.Number("1"), "2"},
+		"empty":   map[string]interface{}{},
+	}
+
+	for name, value := range wanted {
+		if data := resource.Data()[name]; !reflect.DeepEqual(data, value) {
+			t.Errorf("Data %s is %#v, want %#v", name, data, value)
+		}
+	}
+}
+
+func TestXMLDecoderRoundTrip(t *testing.T) {
+	documents := []string{
+		decoderTestDocument,
+		`{}`,
+		`{"_links": {"self": {"href": "/docwhoapi/doctors", "title": "Doctors"}}, "nested": [[1, 2], {"a": [true]}]}`,
+		`{"_links": {"self": [{"href": "/docwhoapi/doctors"}, {"href": "/docwhoapi/doctors?page=1"}]}}`,
+		`{"_links": {"self": [{"href": "/docwhoapi/doctors"}]}, "_embedded": {"item": [{"name": "William Hartnell"}], "first": {"name": "Susan Foreman"}}}`,
+	}
+
+	for _, document := range documents {
+		resource, err := NewDecoder().FromJSON([]byte(document))
+
+		if err != nil {
+			t.Fatalf("FromJSON returns error: %s", err)
+		}
+
+		xmlBytes, err := NewXMLEncoder().ToXML(resource)
+
+		if err != nil {
+			t.Fatalf("ToXML returns error: %s", err)
+		}
+
+		decoded, err := NewXMLDecoder().FromXML(xmlBytes)
+
+		if err != nil {
+			t.Fatalf("FromXML returns error for %s: %s", string(xmlBytes), err)
+		}
+
+		jsonBytes, err := NewEncoder().ToJSON(decoded)
+
+		if err != nil {
+			t.Fatalf("ToJSON returns error: %s", err)
+		}
+
+		var wanted, value interface{}
+		_ = json.Unmarshal([]byte(document), &wanted)
+		_ = json.Unmarshal(jsonBytes, &value)
+
+		if !reflect.DeepEqual(value, wanted) {
+			t.Errorf("JSON value == %s, want %s", string(jsonBytes), document)
+		}
+	}
+}
+
+func TestXMLDecoderWithStrictDecoding(t *testing.T) {
+	document := `<resource><link rel="doc:companions" href="/docwhoapi/companions"/></resource>`
+
+	if _, err := NewXMLDecoder().FromXML([]byte(document)); err != nil {
+		t.Errorf("FromXML returns error: %s", err)
+	}
+
+	if _, err := NewXMLDecoder(WithStrictDecoding()).FromXML([]byte(document)); err == nil {
+		t.Errorf("FromXML should return an error for undefined CURIE")
+	}
+}
+
+func TestXMLDecoderWithSurroundingWhitespace(t *testing.T) {
+	document := "<resource><count type=\"number\">\n\t-1.5e3 </count><regular type=\"boolean\"> true\r\n</regular></resource>"
+
+	resource, err := NewXMLDecoder().FromXML([]byte(document))
+
+	if err != nil {
+		t.Fatalf("FromXML returns error: %s", err)
+	}
+
+	if count := resource.Data()["count"]; count != json.Number("-1.5e3") {
+		t.Errorf("Count is %#v, want %#v", count, json.Number("-1.5e3"))
+	}
+
+	if regular := resource.Data()["regular"]; regular != true {
+		t.Errorf("Regular is %#v, want %#v", regular, true)
+	}
+}
+
+func TestXMLDecoderWithInvalidDocuments(t *testing.T) {
+	documents := []string{
+		``,
+		`<!-- no element -->`,
+		`<doctors/>`,
+		`<resource>`,
+		`<resource></resource><resource></resource>`,
+		`<resource><link rel="" href="/docwhoapi/doctors"/></resource>`,
+		`<resource><count type="number">twelve</count></resource>`,
+		`<resource><count type="number">"12"</count></resource>`,
+		`<resource><count type="number">[12]</count></resource>`,
+		`<resource><count type="number">12 13</count></resource>`,
+		`<resource><count type="number">012</count></resource>`,
+		`<resource><count type="number">+12</count></resource>`,
+		`<resource><count type="number">1.</count></resource>`,
+		`<resource><count type="number">0x12</count></resource>`,
+		`<resource><count type="number"> </count></resource>`,
+		`<resource><count type="number">12&#xA0;</count></resource>`,
+		`<resource><regular type="boolean">yes</regular></resource>`,
+		`<resource><regular type="date">1963-11-23</regular></resource>`,
+		`<resource><seasons type="array"><item type="number">x</item></seasons></resource>`,
+		`<resource><_links>none</_links></resource>`,
+		`<resource><resource rel="doctors"><count type="number">x</count></resource></resource>`,
+	}
+
+	decoder := NewXMLDecoder()
+
+	for _, document := range documents {
+		if _, err := decoder.FromXML([]byte(document)); err == nil {
+			t.Errorf("FromXML should return an error for %q", document)
+		}
+	}
+
+	if _, err := decoder.FromXML([]byte(`<resource><_embedded/></resource>`)); !errors.Is(err, ErrReservedProperty) {
+		t.Errorf("FromXML error is %v, want %v", err, ErrReservedProperty)
+	}
+}
@@ -0,0 +1,447 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+// ErrInvalidXMLName is returned by an XMLEncoder for data property names not being valid XML names.
+var ErrInvalidXMLName = errors.New("not a valid XML element name")
+
+// ErrInvalidXMLChar is returned by an XMLEncoder for texts containing characters not allowed in XML documents.
+var ErrInvalidXMLChar = errors.New("contains characters not allowed in XML")
+
+// XMLEncoder to encode a Resource into a HAL+XML document (application/hal+xml).
+type XMLEncoder interface {
+	ToXML(resource Resource) ([]byte, error)
+}
+
+type xmlEncoder struct {
+	options encoderOptions
+}
+
+// NewXMLEncoder creates a HAL+XML encoder. WithOrderedProperties, WithIndent, WithTrailingNewline,
+// WithStrictEncoding and WithBaseURL are supported.
+func NewXMLEncoder(options ...EncoderOption) XMLEncoder {
+	return &xmlEncoder{options: newEncoderOptions(options)}
+}
+
+// ToXML generates a HAL+XML document from provided Resource as described by
+// https://tools.ietf.org/html/draft-kelly-json-hal-00 and http://stateless.co/hal_specification.html.
+//
+// A Resource is written as resource element. The href of a self link is written as href
+// attribute, links as link elements with rel attribute and embedded resources as resource
+// elements with rel attribute. CURIE links are written as link elements with rel="curies".
+//
+// Data properties are written as elements. Strings are written as text. Other values get a type
+// attribute: number, boolean, null, array or object. Array elements are written as item elements.
+//
+// Texts containing characters not allowed in XML 1.0 documents, like most control characters,
+// are not replaced but result in an ErrInvalidXMLChar error.
+//
+// HAL+XML does not distinguish between single values and arrays of links or embedded resources.
+// The element of a relation being an array of a single link or embedded resource therefore gets an
+// array="true" attribute.
+func (enc *xmlEncoder) ToXML(resource Resource) ([]byte, error) {
+	if enc.options.strict {
+		if err := CheckCompliance(resource); err != nil {
+			return nil, err
+		}
+	}
+
+	jsonOptions := enc.options
+	jsonOptions.indented = false
+	jsonOptions.trailingNewline = false
+	jsonBuffer := new(bytes.Buffer)
+
+	if err := encode(jsonBuffer, resource, jsonOptions); err != nil {
+		return nil, err
+	}
+
+	node, err := parseJSONNode(jsonBuffer.Bytes())
+
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := xml.NewEncoder(buffer)
+
+	if enc.options.indented {
+		encoder.Indent(enc.options.prefix, enc.options.indent)
+	}
+
+	if err := writeXMLResource(encoder, node, "", false); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+
+	if enc.options.trailingNewline {
+		buffer.WriteString("\n")
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeXMLResource(encoder *xml.Encoder, node *jsonNode, rel string, array bool) error {
+	start := xml.StartElement{Name: xml.Name{Local: "resource"}}
+
+	if rel != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "rel"}, Value: rel})
+	}
+
+	links := node.member(LinksProperty)
+	selfHref := ""
+
+	// a self link with href only is written as href attribute of the resource
+	if self := links.member("self"); self != nil && self.kind == jsonObject && len(self.names) == 1 && self.names[0] == "href" {
+		selfHref = self.members[0].text
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "href"}, Value: selfHref})
+	}
+
+	if array {
+		start.Attr = append(start.Attr, arrayAttr)
+	}
+
+	if err := encodeXMLToken(encoder, start); err != nil {
+		return err
+	}
+
+	for i, name := range links.objectNames() {
+		if name == "self" && selfHref != "" {
+			continue
+		}
+
+		for j, link := range links.members[i].values() {
+			if err := writeXMLLink(encoder, link, name, j == 0 && isSingleElementArray(links.members[i], name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	embedded := node.member(EmbeddedProperty)
+
+	for i, name := range embedded.objectNames() {
+		for j, resource := range embedded.members[i].values() {
+			if err := writeXMLResource(encoder, resource, name, j == 0 && isSingleElementArray(embedded.members[i], name)); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	for i, name := range node.objectNames() {
+		if name == LinksProperty || name == EmbeddedProperty {
+			continue
+		}
+
+		if err := writeXMLData(encoder, name, node.members[i]); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+func writeXMLLink(encoder *xml.Encoder, link *jsonNode, rel string, array bool) error {
+	start := xml.StartElement{Name: xml.Name{Local: "link"}, Attr: []xml.Attr{{Name: xml.Name{Local: "rel"}, Value: rel}}}
+
+	for i, name := range link.objectNames() {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: name}, Value: link.members[i].text})
+	}
+
+	if array {
+		start.Attr = append(start.Attr, arrayAttr)
+	}
+
+	if err := encodeXMLToken(encoder, start); err != nil {
+		return err
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+// arrayAttr marks the link or resource element of a relation with a single element array.
+var arrayAttr = xml.Attr{Name: xml.Name{Local: "array"}, Value: "true"}
+
+// isSingleElementArray checks a relation being an array of one link or embedded resource.
+// CURIE links are always an array and need no marker.
+func isSingleElementArray(node *jsonNode, rel string) bool {
+	return node.kind == jsonArray && len(node.members) == 1 && rel != relationtype.CURIES
+}
+
+func writeXMLData(encoder *xml.Encoder, name string, node *jsonNode) error {
+	if !isXMLName(name) {
+		return fmt.Errorf("%q: %w", name, ErrInvalidXMLName)
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	if node.kind != jsonString && (node.kind != jsonObject || len(node.members) == 0) {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "type"}, Value: node.kind.String()})
+	}
+
+	if err := encodeXMLToken(encoder, start); err != nil {
+		return err
+	}
+
+	switch node.kind {
+	case jsonObject:
+		for i, memberName := range node.names {
+			if err := writeXMLData(encoder, memberName, node.members[i]); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	case jsonArray:
+		for _, item := range node.members {
+			if err := writeXMLData(encoder, "item", item); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	case jsonNull:
+	default:
+		if err := encodeXMLToken(encoder, xml.CharData(node.text)); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+// encodeXMLToken encodes a token after checking text and attribute values for characters not allowed in XML.
+// xml.Encoder would silently replace them.
+func encodeXMLToken(encoder *xml.Encoder, token xml.Token) error {
+	switch value := token.(type) {
+	case xml.StartElement:
+		for _, attr := range value.Attr {
+			if !isXMLText(attr.Value) {
+				return fmt.Errorf("%s attribute %q: %w", attr.Name.Local, attr.Value, ErrInvalidXMLChar)
+			}
+		}
+	case xml.CharData:
+		if !isXMLText(string(value)) {
+			return fmt.Errorf("%q: %w", string(value), ErrInvalidXMLChar)
+		}
+	}
+
+	return encoder.EncodeToken(token)
+}
+
+// isXMLText checks a text containing only characters matching the Char production of XML 1.0.
+func isXMLText(text string) bool {
+	for _, c := range text {
+		if c == '\t' || c == '\n' || c == '\r' ||
+			(c >= 0x20 && c <= 0xD7FF) || (c >= 0xE000 && c <= 0xFFFD) || (c >= 0x10000 && c <= 0x10FFFF) {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// isXMLName checks a name being a valid XML element name without namespace prefix.
+func isXMLName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		if c == '_' || unicode.IsLetter(c) || (i > 0 && (c == '-' || c == '.' || unicode.IsDigit(c))) {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+type jsonKind int
+
+const (
+	jsonString jsonKind = iota
+	jsonNumber
+	jsonBoolean
+	jsonNull
+	jsonArray
+	jsonObject
+)
+
+// String returns the name of a kind used as type attribute value.
+func (k jsonKind) String() string {
+	return [...]string{"string", "number", "boolean", "null", "array", "object"}[k]
+}
+
+// jsonNode is a JSON value keeping the order of object members. Scalar values are kept as text.
+type jsonNode struct {
+	kind    jsonKind
+	text    string
+	names   []string
+	members []*jsonNode
+}
+
+// member returns the member of an object with provided name or nil.
+func (n *jsonNode) member(name string) *jsonNode {
+	if n == nil || n.kind != jsonObject {
+		return nil
+	}
+
+	for i, memberName := range n.names {
+		if memberName == name {
+			return n.members[i]
+		}
+	}
+
+	return nil
+}
+
+// objectNames returns the member names of an object, nil for all other values.
+func (n *jsonNode) objectNames() []string {
+	if n == nil || n.kind != jsonObject {
+		return nil
+	}
+
+	return n.names
+}
+
+// values returns the items of an array, no value for null and the value itself for all others.
+func (n *jsonNode) values() []*jsonNode {
+	switch n.kind {
+	case jsonArray:
+		return n.members
+	case jsonNull:
+		return nil
+	}
+
+	return []*jsonNode{n}
+}
+
+func parseJSONNode(data []byte) (*jsonNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	node, err := readJSONNode(decoder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+
+	return node, nil
+}
+
+func readJSONNode(decoder *json.Decoder) (*jsonNode, error) {
+	token, err := decoder.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		node := &jsonNode{kind: jsonArray}
+
+		if value == '{' {
+			node.kind = jsonObject
+		}
+
+		for decoder.More() {
+			if node.kind == jsonObject {
+				name, err := decoder.Token()
+
+				if err != nil {
+					return nil, err
+				}
+
+				node.names = append(node.names, name.(string))
+			}
+
+			member, err := readJSONNode(decoder)
+
+			if err != nil {
+				return nil, err
+			}
+
+			node.members = append(node.members, member)
+		}
+
+		// consume closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	case string:
+		return &jsonNode{kind: jsonString, text: value}, nil
+	case json.Number:
+		return &jsonNode{kind: jsonNumber, text: value.String()}, nil
+	case bool:
+		return &jsonNode{kind: jsonBoolean, text: fmt.Sprint(value)}, nil
+	}
+
+	return &jsonNode{kind: jsonNull}, nil
+}
+
+// writeJSON writes a jsonNode as JSON.
+func (n *jsonNode) writeJSON(buffer *bytes.Buffer) error {
+	switch n.kind {
+	case jsonString:
+		text, err := json.Marshal(n.text)
+
+		if err != nil {
+			return err
+		}
+
+		buffer.Write(text)
+	case jsonNumber, jsonBoolean:
+		buffer.WriteString(n.text)
+	case jsonNull:
+		buffer.WriteString("null")
+	case jsonArray, jsonObject:
+		open, end := byte('['), byte(']')
+
+		if n.kind == jsonObject {
+			open, end = '{', '}'
+		}
+
+		buffer.WriteByte(open)
+
+		for i, member := range n.members {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+
+			if n.kind == jsonObject {
+				name, _ := json.Marshal(n.names[i])
+				buffer.Write(name)
+				buffer.WriteByte(':')
+			}
+
+			if err := member.writeJSON(buffer); err != nil {
+				return err
+			}
+		}
+
+		buffer.WriteByte(end)
+	}
+
+	return nil
+}
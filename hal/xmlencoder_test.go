@@ -0,0 +1,206 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package hal
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal/relationtype"
+)
+
+func TestXMLEncoder(t *testing.T) {
+	wanted := `<resource></resource>`
+
+	encoder := NewXMLEncoder()
+	bytes, err := encoder.ToXML(NewResourceObject())
+
+	if err != nil {
+		t.Fatalf("ToXML returns error: %s", err)
+	}
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("XML value == %s, want %s", value, wanted)
+	}
+}
+
+func TestXMLEncoderWithResource(t *testing.T) {
+	wanted := `<resource href="/docwhoapi/doctors">` +
+		`<link rel="curies" href="http://example.com/docs/relations/{rel}" templated="true" name="doc"></link>` +
+		`<link rel="doc:companions" href="/docwhoapi/companions" title="Companions"></link>` +
+		`<link rel="doc:companions" href="/docwhoapi/companions?page=2"></link>` +
+		`<resource rel="doc:doctors" href="/docwhoapi/doctors/1"><name>William Hartnell</name></resource>` +
+		`<content>All actors &amp; doctors.</content>` +
+		`<details><from>1963</from><until type="null"></until></details>` +
+		`<doctorCount type="number">12</doctorCount>` +
+		`<empty type="object"></empty>` +
+		`<regular type="boolean">true</regular>` +
+		`<seasons type="array"><item type="number">1</item><item>2</item></seasons>` +
+		`</resource>`
+
+	root := NewResourceObject()
+	root.AddLink(selfRelation("/docwhoapi/doctors"))
+	curieLink, _ := NewCurieLink("doc", "http://example.com/docs/relations/{rel}")
+	root.AddCurieLinks([]*LinkObject{curieLink})
+
+	companions, _ := NewLinkRelation("companions")
+	companions.SetCurieLink(curieLink)
+	companions.SetLinks([]*LinkObject{{Href: "/docwhoapi/companions", Title: "Companions"}, {Href: "/docwhoapi/companions?page=2"}})
+	root.AddLink(companions)
+
+	doctor := NewResourceObject()
+	doctor.AddLink(selfRelation("/docwhoapi/doctors/1"))
+	doctor.Data()["name"] = "William Hartnell"
+	doctors, _ := NewResourceRelation("doctors")
+	doctors.SetCurieLink(curieLink)
+	doctors.SetResource(doctor)
+	root.AddResource(doctors)
+
+	root.Data()["content"] = "All actors & doctors."
+	root.Data()["details"] = map[string]interface{}{"from": "1963", "until": nil}
+	root.Data()["doctorCount"] = 12
+	root.Data()["empty"] = map[string]interface{}{}
+	root.Data()["regular"] = true
+	root.Data()["seasons"] = []interface{}{1, "2"}
+
+	bytes, err := NewXMLEncoder().ToXML(root)
+
+	if err != nil {
+		t.Fatalf("ToXML returns error: %s", err)
+	}
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("XML value == %s, want %s", value, wanted)
+	}
+}
+
+func TestXMLEncoderWithIndent(t *testing.T) {
+	wanted := "<resource href=\"/docwhoapi/doctors\">\n\t<name>Doctors</name>\n</resource>\n"
+
+	root := NewResourceObject()
+	root.AddLink(selfRelation("/docwhoapi/doctors"))
+	root.Data()["name"] = "Doctors"
+
+	bytes, err := NewXMLEncoder(WithIndent("", "\t"), WithTrailingNewline()).ToXML(root)
+
+	if err != nil {
+		t.Fatalf("ToXML returns error: %s", err)
+	}
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("XML value == %q, want %q", value, wanted)
+	}
+}
+
+func TestXMLEncoderWithBaseURL(t *testing.T) {
+	wanted := `<resource href="http://example.com/docwhoapi/doctors"></resource>`
+
+	base, _ := url.Parse("http://example.com/docwhoapi/")
+	root := NewResourceObject()
+	root.AddLink(selfRelation("doctors"))
+
+	bytes, err := NewXMLEncoder(WithBaseURL(base)).ToXML(root)
+
+	if err != nil {
+		t.Fatalf("ToXML returns error: %s", err)
+	}
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("XML value == %s, want %s", value, wanted)
+	}
+}
+
+func TestXMLEncoderWithSingleElementArray(t *testing.T) {
+	wanted := `<resource><link rel="alternate" href="/doctors.xml" array="true"></link>` +
+		`<resource rel="item" array="true"><name>William Hartnell</name></resource></resource>`
+
+	alternate, _ := NewLinkRelation("alternate")
+	alternate.SetLinks([]*LinkObject{{Href: "/doctors.xml"}})
+
+	doctor := NewResourceObject()
+	doctor.Data()["name"] = "William Hartnell"
+	item, _ := NewResourceRelation(relationtype.Item)
+	item.SetResources([]Resource{doctor})
+
+	root := NewResourceObject()
+	root.AddLink(alternate)
+	root.AddResource(item)
+
+	bytes, err := NewXMLEncoder().ToXML(root)
+
+	if err != nil {
+		t.Fatalf("ToXML returns error: %s", err)
+	}
+
+	if value := string(bytes); value != wanted {
+		t.Errorf("XML value == %s, want %s", value, wanted)
+	}
+}
+
+func TestXMLEncoderWithInvalidName(t *testing.T) {
+	names := []string{"1st", "first name", "doc:name", "-name", ""}
+
+	for _, name := range names {
+		root := NewResourceObject()
+		root.Data()["details"] = map[string]interface{}{name: "value"}
+
+		if _, err := NewXMLEncoder().ToXML(root); !errors.Is(err, ErrInvalidXMLName) {
+			t.Errorf("ToXML error for %q is %v, want %v", name, err, ErrInvalidXMLName)
+		}
+	}
+}
+
+func TestXMLEncoderWithInvalidChar(t *testing.T) {
+	root := NewResourceObject()
+	root.Data()["name"] = "a\u0001b"
+
+	if _, err := NewXMLEncoder().ToXML(root); !errors.Is(err, ErrInvalidXMLChar) {
+		t.Errorf("ToXML error for data is %v, want %v", err, ErrInvalidXMLChar)
+	}
+
+	root = NewResourceObject()
+	self := selfRelation("/docs")
+	self.Links()[0].Title = "first\u0008"
+	root.AddLink(self)
+
+	if _, err := NewXMLEncoder().ToXML(root); !errors.Is(err, ErrInvalidXMLChar) {
+		t.Errorf("ToXML error for link is %v, want %v", err, ErrInvalidXMLChar)
+	}
+
+	root = NewResourceObject()
+	root.Data()["name"] = "a\tb\nc"
+
+	if _, err := NewXMLEncoder().ToXML(root); err != nil {
+		t.Errorf("ToXML error for whitespace is %v, want nil", err)
+	}
+}
+
+func TestIsXMLText(t *testing.T) {
+	texts := map[string]bool{"text": true, "a\tb\r\n": true, "名前 😀": true, "a\u0000b": false, "\u001f": false, "\ufffe": false}
+
+	for text, wanted := range texts {
+		if value := isXMLText(text); value != wanted {
+			t.Errorf("isXMLText(%q) is %v, want %v", text, value, wanted)
+		}
+	}
+}
+
+func TestIsXMLName(t *testing.T) {
+	names := map[string]bool{"name": true, "_name": true, "first-name.2": true, "名前": true, "1st": false, "a b": false}
+
+	for name, wanted := range names {
+		if value := isXMLName(name); value != wanted {
+			t.Errorf("isXMLName(%q) is %v, want %v", name, value, wanted)
+		}
+	}
+}
+
+func selfRelation(href string) LinkRelation {
+	self, _ := NewLinkRelation(relationtype.Self)
+	self.SetLink(&LinkObject{Href: href})
+
+	return self
+}
@@ -4,23 +4,52 @@
 
 package halforms
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pmoule/go2hal/hal"
+)
 
 type Encoder interface {
 	ToJSON(document Document) ([]byte, error)
 }
 
+// StreamEncoder to encode a Document into a HAL-FORMS document written to an io.Writer.
+type StreamEncoder interface {
+	Encode(document Document) error
+}
+
 type standardEncoder struct {
+	options []hal.EncoderOption
 }
 
-// NewEncoder creates a JSON encoder
-func NewEncoder() Encoder {
-	return new(standardEncoder)
+// NewEncoder creates a JSON encoder. All hal.EncoderOption values are supported.
+func NewEncoder(options ...hal.EncoderOption) Encoder {
+	return &standardEncoder{options: options}
 }
 
 // ToJSON generates a HAL-FORMS document from provided Document.
 func (enc *standardEncoder) ToJSON(document Document) ([]byte, error) {
+	if len(enc.options) > 0 {
+		return hal.NewEncoder(enc.options...).ToJSON(document.resource())
+	}
+
 	namedMap := document.ToMap()
 
 	return json.Marshal(namedMap.Content)
 }
+
+type streamEncoder struct {
+	encoder hal.StreamEncoder
+}
+
+// NewStreamEncoder creates a JSON encoder writing to w. All hal.EncoderOption values are supported.
+func NewStreamEncoder(w io.Writer, options ...hal.EncoderOption) StreamEncoder {
+	return &streamEncoder{encoder: hal.NewStreamEncoder(w, options...)}
+}
+
+// Encode writes the HAL-FORMS document of provided Document.
+func (enc *streamEncoder) Encode(document Document) error {
+	return enc.encoder.Encode(document.resource())
+}
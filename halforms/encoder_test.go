@@ -0,0 +1,72 @@
+// go2hal v0.6.0
+// Copyright (c) 2021 Patrick Moule
+// License: MIT
+
+package halforms
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pmoule/go2hal/hal"
+)
+
+func createTestDocument() Document {
+	document := NewDocument("/docwhoapi/hal-forms/create-doctor")
+	template := NewTemplate()
+	template.Method = http.MethodPost
+	template.Target = "/docwhoapi/doctors"
+	property := NewProperty("name")
+	property.Placeholder = "<the doctor's name>"
+	template.Properties = append(template.Properties, property)
+	document.AddTemplate(template)
+
+	return document
+}
+
+func TestStreamEncoder(t *testing.T) {
+	document := createTestDocument()
+	wanted, _ := NewEncoder().ToJSON(document)
+
+	buffer := new(bytes.Buffer)
+	encoder := NewStreamEncoder(buffer)
+
+	if err := encoder.Encode(document); err != nil {
+		t.Fatalf("Encode returns error: %s", err)
+	}
+
+	if value := buffer.String(); value != string(wanted) {
+		t.Errorf("JSON value == %s, want %s", value, string(wanted))
+	}
+
+	namedMap := document.ToMap()
+	wanted, _ = json.MarshalIndent(namedMap.Content, "", "  ")
+	buffer.Reset()
+	encoder = NewStreamEncoder(buffer, hal.WithIndent("", "  "), hal.WithTrailingNewline())
+
+	if err := encoder.Encode(document); err != nil {
+		t.Fatalf("Encode returns error: %s", err)
+	}
+
+	if value := buffer.String(); value != string(wanted)+"\n" {
+		t.Errorf("JSON value == %s, want %s", value, string(wanted))
+	}
+}
+
+func TestEncoderWithOptions(t *testing.T) {
+	document := createTestDocument()
+	namedMap := document.ToMap()
+	wanted, _ := json.MarshalIndent(namedMap.Content, "", "\t")
+
+	bytes, err := NewEncoder(hal.WithIndent("", "\t")).ToJSON(document)
+
+	if err != nil {
+		t.Fatalf("ToJSON returns error: %s", err)
+	}
+
+	if value := string(bytes); value != string(wanted) {
+		t.Errorf("JSON value == %s, want %s", value, string(wanted))
+	}
+}
@@ -6,6 +6,7 @@ package halforms
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/pmoule/go2hal/hal"
 	"github.com/pmoule/go2hal/hal/mapping"
@@ -197,8 +198,9 @@ func (d *Document) Links() mapping.NamedMap {
 }
 
 // AddLinke adds a link relation to HAL-FORMS document.
+// Like hal.Resource, an already existing relation with the same full name, e.g. doc:doctors, is replaced.
 func (d *Document) AddLink(rel hal.LinkRelation) {
-	d.links[rel.Name()] = rel
+	d.links[rel.FullName()] = rel
 }
 
 // Templates returns a "_templates" named map of templates.
@@ -225,6 +227,26 @@ func (d *Document) ToMap() mapping.NamedMap {
 	return mapping.NamedMap{Name: "root", Content: properties}
 }
 
+// resource converts Document to a hal.Resource with templates as "_templates" data property.
+func (d *Document) resource() hal.Resource {
+	resource := hal.NewResourceObject()
+	names := []string{}
+
+	for name := range d.links {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		resource.AddLink(d.links[name])
+	}
+
+	resource.Data()[TemplatesProperty] = d.templates.ToMap().Content
+
+	return resource
+}
+
 // NewDocument returns an initialised Document with "self" link relation to provided href.
 func NewDocument(href string) Document {
 	document := Document{links: map[string]hal.LinkRelation{}, templates: map[string]*Template{}}
@@ -74,3 +74,30 @@ func TestDocument(t *testing.T) {
 		t.Errorf("Generated JSON type: %s, want:  %s", typeValue, MediaTypeIdentifier)
 	}
 }
+
+func TestDocumentAddLinkWithCurie(t *testing.T) {
+	docCurie, _ := hal.NewCurieLink("doc", "http://doc/{rel}")
+	exCurie, _ := hal.NewCurieLink("ex", "http://ex/{rel}")
+	document := NewDocument("/doctors")
+
+	for _, curieLink := range []*hal.LinkObject{docCurie, exCurie, docCurie} {
+		relation, _ := hal.NewLinkRelation("doctors")
+		relation.SetCurieLink(curieLink)
+		relation.SetLink(&hal.LinkObject{Href: "/" + curieLink.Name})
+		document.AddLink(relation)
+	}
+
+	links := document.Links().Content
+
+	if count := len(links); count != 3 {
+		t.Fatalf("Link count == %d, want %d", count, 3)
+	}
+
+	wanted := map[string]string{"doc:doctors": "/doc", "ex:doctors": "/ex"}
+
+	for name, href := range wanted {
+		if link, ok := links[name].(*hal.LinkObject); !ok || link.Href != href {
+			t.Errorf("Link %s == %v, want href %s", name, links[name], href)
+		}
+	}
+}